@@ -0,0 +1,151 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// BattingStats has batting totals for a player or team
+type BattingStats struct {
+	AtBats      int    `json:"atBats"`
+	Runs        int    `json:"runs"`
+	Hits        int    `json:"hits"`
+	RBI         int    `json:"rbi"`
+	BaseOnBalls int    `json:"baseOnBalls"`
+	StrikeOuts  int    `json:"strikeOuts"`
+	HomeRuns    int    `json:"homeRuns"`
+	LeftOnBase  int    `json:"leftOnBase"`
+	Avg         string `json:"avg"`
+}
+
+// PitchingStats has pitching totals for a player or team
+type PitchingStats struct {
+	InningsPitched  string `json:"inningsPitched"`
+	Hits            int    `json:"hits"`
+	Runs            int    `json:"runs"`
+	EarnedRuns      int    `json:"earnedRuns"`
+	BaseOnBalls     int    `json:"baseOnBalls"`
+	StrikeOuts      int    `json:"strikeOuts"`
+	HomeRuns        int    `json:"homeRuns"`
+	NumberOfPitches int    `json:"numberOfPitches"`
+	ERA             string `json:"era"`
+}
+
+// Person is a player or coach
+type Person struct {
+	ID       int    `json:"id"`
+	FullName string `json:"fullName"`
+	Stats    []struct {
+		Group struct {
+			DisplayName string `json:"displayName"`
+		} `json:"group"`
+		Splits []struct {
+			Stat struct {
+				Wins   int    `json:"wins"`
+				Losses int    `json:"losses"`
+				ERA    string `json:"era"`
+			} `json:"stat"`
+		} `json:"splits"`
+	} `json:"stats"`
+}
+
+// PitchingRecord returns the season W-L and ERA of a pitcher when hydrated
+func (p *Person) PitchingRecord() (record string, ok bool) {
+	for _, s := range p.Stats {
+		if s.Group.DisplayName != "pitching" || len(s.Splits) == 0 {
+			continue
+		}
+		st := s.Splits[len(s.Splits)-1].Stat
+		return strconv.Itoa(st.Wins) + "-" + strconv.Itoa(st.Losses) + ", " + st.ERA, true
+	}
+	return
+}
+
+// BoxscorePlayer contains a player's stats for the game
+type BoxscorePlayer struct {
+	Person   Person `json:"person"`
+	Position struct {
+		Abbreviation string `json:"abbreviation"`
+	} `json:"position"`
+	BattingOrder string `json:"battingOrder"`
+	Stats        struct {
+		Batting  BattingStats  `json:"batting"`
+		Pitching PitchingStats `json:"pitching"`
+	} `json:"stats"`
+	SeasonStats struct {
+		Batting  BattingStats  `json:"batting"`
+		Pitching PitchingStats `json:"pitching"`
+	} `json:"seasonStats"`
+}
+
+// BoxscoreTeam contains the batting and pitching lines of a team
+type BoxscoreTeam struct {
+	Team      Team `json:"team"`
+	TeamStats struct {
+		Batting  BattingStats  `json:"batting"`
+		Pitching PitchingStats `json:"pitching"`
+	} `json:"teamStats"`
+	Players  map[string]BoxscorePlayer `json:"players"`
+	Pitchers []int                     `json:"pitchers"`
+}
+
+// Boxscore of a game
+type Boxscore struct {
+	Teams struct {
+		Away BoxscoreTeam `json:"away"`
+		Home BoxscoreTeam `json:"home"`
+	} `json:"teams"`
+}
+
+// Batters returns the players that appeared in the lineup in batting order
+func (bt *BoxscoreTeam) Batters() (batters []BoxscorePlayer) {
+
+	for _, p := range bt.Players {
+		if p.BattingOrder != "" {
+			batters = append(batters, p)
+		}
+	}
+
+	sort.Slice(batters, func(i, j int) bool {
+		a, _ := strconv.Atoi(batters[i].BattingOrder)
+		b, _ := strconv.Atoi(batters[j].BattingOrder)
+		return a < b
+	})
+
+	return
+}
+
+// PitchersUsed returns the pitchers that appeared in order of appearance
+func (bt *BoxscoreTeam) PitchersUsed() (pitchers []BoxscorePlayer) {
+
+	for _, id := range bt.Pitchers {
+		if p, ok := bt.Players["ID"+strconv.Itoa(id)]; ok {
+			pitchers = append(pitchers, p)
+		}
+	}
+
+	return
+}
+
+// GetBoxscore gets the box score of a game
+func GetBoxscore(url string, gamePk int) (b Boxscore, err error) {
+
+	log.WithFields(log.Fields{
+		"gamePk": gamePk,
+	}).Debug("Getting box score")
+
+	resp, err := httpGet(fmt.Sprintf(url, gamePk))
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&b)
+
+	return
+}
@@ -9,15 +9,34 @@ import (
 
 // Config hold app configuration options
 type Config struct {
-	StatsURL          string `json:"statsURL"`
-	StreamPlaylistURL string `json:"streamPlaylistURL"`
-	CheckStreams      bool   `json:"checkStreams"`
+	StatsURL          string   `json:"statsURL"`
+	StreamPlaylistURL string   `json:"streamPlaylistURL"`
+	BoxscoreURL       string   `json:"boxscoreURL"`
+	LiveFeedURL       string   `json:"liveFeedURL"`
+	StandingsURL      string   `json:"standingsURL"`
+	TeamsURL          string   `json:"teamsURL"`
+	Level             string   `json:"level"`
+	Favorites         []string `json:"favorites"`
+	NoSpoilers        bool     `json:"noSpoilers"`
+	SpoilerTeams      []string `json:"spoilerTeams"`
+	Compact           bool     `json:"compact"`
+	Notify            string   `json:"notify"`
+	CheckStreams      bool     `json:"checkStreams"`
+	Theme             Theme    `json:"theme"`
 	Proxy             struct {
 		Domain        string `json:"domain"`
 		SourceDomains string `json:"sourceDomains"`
 	} `json:"proxy"`
 }
 
+const (
+	defaultBoxscoreURL = "https://statsapi.mlb.com/api/v1/game/%d/boxscore"
+	defaultLiveFeedURL = "https://statsapi.mlb.com/api/v1.1/game/%d/feed/live"
+	defaultTeamsURL    = "https://statsapi.mlb.com/api/v1/teams?sportId=1"
+	// season, standings type
+	defaultStandingsURL = "https://statsapi.mlb.com/api/v1/standings?leagueId=103,104&season=%d&standingsTypes=%s&hydrate=team,division,league"
+)
+
 // LoadConfig - load configuration from JSON file
 func LoadConfig(file string) (config *Config, err error) {
 
@@ -45,6 +64,34 @@ func LoadConfig(file string) (config *Config, err error) {
 		err = errors.New("set statsURL in configuration file")
 	}
 
+	if config.BoxscoreURL == "" {
+		config.BoxscoreURL = defaultBoxscoreURL
+	}
+
+	if config.LiveFeedURL == "" {
+		config.LiveFeedURL = defaultLiveFeedURL
+	}
+
+	if config.StandingsURL == "" {
+		config.StandingsURL = defaultStandingsURL
+	}
+
+	if config.TeamsURL == "" {
+		config.TeamsURL = defaultTeamsURL
+	}
+
+	if config.Level != "" && config.SetLevel(config.Level) != nil {
+		err = fmt.Errorf("set level to one of %s in configuration file", LevelNames())
+	}
+
+	if e := config.Theme.setDefaults(); e != nil {
+		err = fmt.Errorf("%s in theme, use colors and attributes such as bold green or none", e)
+	}
+
+	if e := CheckNotifyMethod(config.Notify); e != nil {
+		err = fmt.Errorf("%s in configuration file", e)
+	}
+
 	if config.CheckStreams {
 		if config.StreamPlaylistURL == "" {
 			err = errors.New("set streamPlaylistURL in configuration file")
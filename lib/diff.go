@@ -0,0 +1,201 @@
+package lib
+
+import (
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// event types
+const (
+	EventGameStarted     = "gameStarted"
+	EventRunScored       = "runScored"
+	EventLeadChange      = "leadChange"
+	EventInningChanged   = "inningChanged"
+	EventDelayed         = "delayed"
+	EventFinal           = "final"
+	EventStreamAvailable = "streamAvailable"
+	EventStreamEnded     = "streamEnded"
+	EventExtraInnings    = "extraInnings"
+	EventCloseGame       = "closeGame"
+	EventNoHitter        = "noHitter"
+	// EventRefresh is sent after each refresh, whether or not anything changed
+	EventRefresh = "refresh"
+)
+
+// Event is a change to a game found when the schedule is refreshed. Team is
+// the abbreviation of the team that scored, took the lead or is pitching a
+// no-hitter.
+type Event struct {
+	Type   string        `json:"type"`
+	GamePk int           `json:"gamePk,omitempty"`
+	Team   string        `json:"team,omitempty"`
+	Stream *StreamOutput `json:"stream,omitempty"`
+	Game   *GameOutput   `json:"game,omitempty"`
+}
+
+// DiffSchedules compares successive schedules and their streams and returns
+// the changes to the games in both, in the order of the current schedule.
+func DiffSchedules(prev, cur *Schedule, prevStreams, curStreams map[int]map[string]*Stream) (events []Event) {
+
+	// a different range of days isn't a change to the games
+	if prev.Date != cur.Date || prev.EndDate != cur.EndDate {
+		return
+	}
+
+	for _, d := range cur.Dates {
+		for i := range d.Games {
+
+			g := &d.Games[i]
+
+			p, ok := prev.GameMap[g.GamePk]
+			if !ok {
+				continue
+			}
+
+			events = append(events, diffGame(&p, g)...)
+			events = append(events, diffStreams(g.GamePk, prevStreams[g.GamePk], curStreams[g.GamePk])...)
+		}
+	}
+
+	for _, e := range events {
+		log.WithFields(log.Fields{
+			"type":   e.Type,
+			"gamePK": e.GamePk,
+			"team":   e.Team,
+		}).Debug("Game changed")
+	}
+
+	return
+}
+
+// diffGame finds the changes to the state, score and inning of a game
+func diffGame(prev, cur *Game) (events []Event) {
+
+	ps, cs := prev.GameStatus.DetailedState, cur.GameStatus.DetailedState
+	add := func(t, team string) {
+		events = append(events, Event{Type: t, GamePk: cur.GamePk, Team: team})
+	}
+
+	if !hasGameStarted(ps) && hasGameStarted(cs) {
+		add(EventGameStarted, "")
+	}
+
+	if !isDelayed(ps) && isDelayed(cs) {
+		add(EventDelayed, "")
+	}
+
+	pa, ph := prev.LineScore.Scoring.Away.Runs, prev.LineScore.Scoring.Home.Runs
+	ca, ch := cur.LineScore.Scoring.Away.Runs, cur.LineScore.Scoring.Home.Runs
+
+	// runs taken away by a scoring change aren't events
+	if ca > pa {
+		add(EventRunScored, cur.Teams.Away.Team.Abbreviation)
+	}
+	if ch > ph {
+		add(EventRunScored, cur.Teams.Home.Team.Abbreviation)
+	}
+
+	// the first runs of a game don't change the lead
+	if l := leader(cur); l != "" && l != leader(prev) && pa+ph > 0 {
+		add(EventLeadChange, l)
+	}
+
+	if isActiveGame(cs) && (prev.LineScore.CurrentInning != cur.LineScore.CurrentInning ||
+		prev.LineScore.InningState != cur.LineScore.InningState) {
+		add(EventInningChanged, "")
+	}
+
+	if isActiveGame(cs) {
+
+		scheduled := scheduledInnings(cur)
+
+		if cur.LineScore.CurrentInning > scheduled && prev.LineScore.CurrentInning <= scheduled {
+			add(EventExtraInnings, "")
+		}
+
+		if isCloseLateGame(cur) && !isCloseLateGame(prev) {
+			add(EventCloseGame, "")
+		}
+
+		// a team pitching a no-hitter hasn't allowed a hit to the other
+		if isNoHitter(cur, &cur.LineScore.Scoring.Away) && !isNoHitter(prev, &prev.LineScore.Scoring.Away) {
+			add(EventNoHitter, cur.Teams.Home.Team.Abbreviation)
+		}
+		if isNoHitter(cur, &cur.LineScore.Scoring.Home) && !isNoHitter(prev, &prev.LineScore.Scoring.Home) {
+			add(EventNoHitter, cur.Teams.Away.Team.Abbreviation)
+		}
+	}
+
+	if !isCompleteGame(ps) && isCompleteGame(cs) {
+		add(EventFinal, "")
+	}
+
+	return
+}
+
+// scheduledInnings is the length of a game without extra innings
+func scheduledInnings(g *Game) int {
+	if g.LineScore.ScheduledInnings > 0 {
+		return g.LineScore.ScheduledInnings
+	}
+	return 9
+}
+
+// isCloseLateGame is true for a game in play within a run from the second to last inning on
+func isCloseLateGame(g *Game) bool {
+
+	diff := g.LineScore.Scoring.Away.Runs - g.LineScore.Scoring.Home.Runs
+
+	return isActiveGame(g.GameStatus.DetailedState) && diff >= -1 && diff <= 1 &&
+		g.LineScore.CurrentInning >= scheduledInnings(g)-1
+}
+
+// isNoHitter is true for a game in play past the 6th where the team batting hasn't had a hit
+func isNoHitter(g *Game, batting *Score) bool {
+	return isActiveGame(g.GameStatus.DetailedState) && g.LineScore.CurrentInning > 6 && batting.Hits == 0
+}
+
+// diffStreams finds the streams of a game that started or ended
+func diffStreams(gamePk int, prev, cur map[string]*Stream) (events []Event) {
+
+	add := func(t string, streams, other map[string]*Stream) {
+		var ids []string
+		for id := range streams {
+			if _, ok := other[id]; !ok {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			o := getStreamOutput(streams[id])
+			events = append(events, Event{Type: t, GamePk: gamePk, Stream: &o})
+		}
+	}
+
+	add(EventStreamAvailable, cur, prev)
+	add(EventStreamEnded, prev, cur)
+
+	return
+}
+
+// leader is the abbreviation of the team ahead, empty when tied
+func leader(g *Game) string {
+
+	away, home := g.LineScore.Scoring.Away.Runs, g.LineScore.Scoring.Home.Runs
+
+	switch {
+	case away > home:
+		return g.Teams.Away.Team.Abbreviation
+	case home > away:
+		return g.Teams.Home.Team.Abbreviation
+	}
+
+	return ""
+}
+
+// isDelayed is true for games delayed before or during play, or suspended
+func isDelayed(state string) bool {
+	return isDelayedSuspended(state) || strings.HasPrefix(state, "Delayed")
+}
@@ -0,0 +1,162 @@
+package lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testGame builds a NYM at ATL game. Both teams have hits so no-hitters
+// don't show up unless a case asks for one.
+func testGame(state string, inning int, half string, away, home int) Game {
+
+	g := Game{GamePk: 1}
+	g.Teams.Away.Team.Abbreviation = "NYM"
+	g.Teams.Home.Team.Abbreviation = "ATL"
+	g.GameStatus.DetailedState = state
+	g.LineScore.CurrentInning = inning
+	g.LineScore.InningState = half
+	g.LineScore.Scoring.Away = Score{Runs: away, Hits: 5}
+	g.LineScore.Scoring.Home = Score{Runs: home, Hits: 5}
+
+	return g
+}
+
+func testSchedule(date string, games ...Game) *Schedule {
+
+	s := &Schedule{Date: date, EndDate: date, GameMap: make(map[int]Game)}
+	s.Dates = []ScheduleDate{{Date: date, Games: games}}
+	for _, g := range games {
+		s.GameMap[g.GamePk] = g
+	}
+
+	return s
+}
+
+func testStreams(ids ...string) map[int]map[string]*Stream {
+
+	streams := map[string]*Stream{}
+	for _, id := range ids {
+		streams[id] = &Stream{GamePk: 1, ID: id, MediaFeedType: "HOME", CallLetters: "SNY"}
+	}
+
+	return map[int]map[string]*Stream{1: streams}
+}
+
+func TestDiffSchedules(t *testing.T) {
+
+	type event struct{ Type, Team, Stream string }
+
+	tests := []struct {
+		name                    string
+		prev, cur               Game
+		prevStreams, curStreams []string
+		want                    []event
+	}{
+		{
+			name: "no change",
+			prev: testGame("In Progress", 3, "Top", 1, 0),
+			cur:  testGame("In Progress", 3, "Top", 1, 0),
+		},
+		{
+			name: "game started",
+			prev: testGame("Warmup", 0, "", 0, 0),
+			cur:  testGame("In Progress", 1, "Top", 0, 0),
+			want: []event{{Type: EventGameStarted}, {Type: EventInningChanged}},
+		},
+		{
+			name: "first run doesn't change the lead",
+			prev: testGame("In Progress", 2, "Top", 0, 0),
+			cur:  testGame("In Progress", 2, "Top", 1, 0),
+			want: []event{{Type: EventRunScored, Team: "NYM"}},
+		},
+		{
+			name: "lead taken from a tie",
+			prev: testGame("In Progress", 5, "Bottom", 2, 2),
+			cur:  testGame("In Progress", 5, "Bottom", 2, 3),
+			want: []event{{Type: EventRunScored, Team: "ATL"}, {Type: EventLeadChange, Team: "ATL"}},
+		},
+		{
+			name: "lead reversed",
+			prev: testGame("In Progress", 5, "Bottom", 2, 1),
+			cur:  testGame("In Progress", 5, "Bottom", 2, 4),
+			want: []event{{Type: EventRunScored, Team: "ATL"}, {Type: EventLeadChange, Team: "ATL"}},
+		},
+		{
+			name: "game tied isn't a lead change",
+			prev: testGame("In Progress", 5, "Bottom", 2, 1),
+			cur:  testGame("In Progress", 5, "Bottom", 2, 2),
+			want: []event{{Type: EventRunScored, Team: "ATL"}},
+		},
+		{
+			name: "inning changed",
+			prev: testGame("In Progress", 4, "Top", 1, 1),
+			cur:  testGame("In Progress", 4, "Bottom", 1, 1),
+			want: []event{{Type: EventInningChanged}},
+		},
+		{
+			name: "delayed",
+			prev: testGame("In Progress", 4, "Top", 0, 1),
+			cur:  testGame("Delayed: Rain", 4, "Top", 0, 1),
+			want: []event{{Type: EventDelayed}},
+		},
+		{
+			name: "final",
+			prev: testGame("In Progress", 9, "Bottom", 3, 1),
+			cur:  testGame("Final", 9, "Bottom", 3, 1),
+			want: []event{{Type: EventFinal}},
+		},
+		{
+			name: "extra innings",
+			prev: testGame("In Progress", 9, "Bottom", 2, 2),
+			cur:  testGame("In Progress", 10, "Top", 2, 2),
+			want: []event{{Type: EventInningChanged}, {Type: EventExtraInnings}},
+		},
+		{
+			name:        "stream added",
+			prev:        testGame("In Progress", 1, "Top", 0, 0),
+			cur:         testGame("In Progress", 1, "Top", 0, 0),
+			prevStreams: []string{"1"},
+			curStreams:  []string{"1", "2"},
+			want:        []event{{Type: EventStreamAvailable, Stream: "2"}},
+		},
+		{
+			name:        "stream removed",
+			prev:        testGame("Final", 9, "Bottom", 0, 1),
+			cur:         testGame("Final", 9, "Bottom", 0, 1),
+			prevStreams: []string{"1", "2"},
+			curStreams:  []string{"2"},
+			want:        []event{{Type: EventStreamEnded, Stream: "1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			events := DiffSchedules(testSchedule("2021-06-01", tt.prev), testSchedule("2021-06-01", tt.cur),
+				testStreams(tt.prevStreams...), testStreams(tt.curStreams...))
+
+			var got []event
+			for _, e := range events {
+				ge := event{Type: e.Type, Team: e.Team}
+				if e.Stream != nil {
+					ge.Stream = e.Stream.ID
+				}
+				got = append(got, ge)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffSchedulesNewDates(t *testing.T) {
+
+	prev := testSchedule("2021-06-01", testGame("In Progress", 3, "Top", 0, 0))
+	cur := testSchedule("2021-06-02", testGame("Final", 9, "Bottom", 5, 0))
+
+	if events := DiffSchedules(prev, cur, testStreams(), testStreams("1")); len(events) > 0 {
+		t.Errorf("got %v for a different date range, want none", events)
+	}
+}
@@ -0,0 +1,80 @@
+package lib
+
+import (
+	"sync"
+)
+
+// eventBuffer is the number of events kept for a subscriber that hasn't read them yet
+const eventBuffer = 64
+
+// DiffSchedule finds the changes to the games on the scoreboard since the
+// schedule and streams from before it was refreshed. Only the starts, ends
+// and streams of games hidden to avoid spoilers are kept.
+func (ui *UI) DiffSchedule(old *Schedule, oldStreams map[int]map[string]*Stream) (events []Event) {
+
+	for _, e := range DiffSchedules(old, ui.schedule, oldStreams, ui.streams) {
+
+		g := ui.schedule.GameMap[e.GamePk]
+
+		if ui.hideSpoilers(&g) {
+			switch e.Type {
+			case EventRunScored, EventLeadChange, EventInningChanged, EventDelayed,
+				EventExtraInnings, EventCloseGame, EventNoHitter:
+				continue
+			}
+		}
+
+		o, _ := ui.GetGameOutput(e.GamePk)
+		e.Game = &o
+		events = append(events, e)
+	}
+
+	return
+}
+
+// EventStream sends the events of each refresh to its subscribers
+type EventStream struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]bool
+}
+
+// NewEventStream creates an EventStream
+func NewEventStream() *EventStream {
+	return &EventStream{subscribers: make(map[chan Event]bool)}
+}
+
+// Subscribe returns a channel that receives the events published
+func (es *EventStream) Subscribe() chan Event {
+
+	ch := make(chan Event, eventBuffer)
+
+	es.mu.Lock()
+	es.subscribers[ch] = true
+	es.mu.Unlock()
+
+	return ch
+}
+
+// Unsubscribe stops sending events to the channel
+func (es *EventStream) Unsubscribe(ch chan Event) {
+	es.mu.Lock()
+	delete(es.subscribers, ch)
+	es.mu.Unlock()
+}
+
+// Publish sends the events to every subscriber. Events are dropped for
+// subscribers too far behind rather than holding up the refresh.
+func (es *EventStream) Publish(events []Event) {
+
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	for ch := range es.subscribers {
+		for _, e := range events {
+			select {
+			case ch <- e:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// game states that can be filtered on
+const (
+	StateLive     = "live"
+	StateFinal    = "final"
+	StateUpcoming = "upcoming"
+)
+
+// division abbreviations by stats API division ID
+var divisions = map[int]string{
+	200: "ALW",
+	201: "ALE",
+	202: "ALC",
+	203: "NLW",
+	204: "NLE",
+	205: "NLC",
+}
+
+// league abbreviations by stats API league ID
+var leagues = map[int]string{
+	103: "AL",
+	104: "NL",
+}
+
+// Filter selects the games to show. Games must match every category set and
+// any of the values within a category.
+type Filter struct {
+	Teams     []string
+	Leagues   []string
+	Divisions []string
+	States    []string
+	HasStream bool
+}
+
+// ParseFilter parses a filter expression of space separated terms, e.g.
+// "team=NYY,BOS state=live streams". Terms without a key are matched against
+// game states, leagues and divisions before being treated as teams.
+func ParseFilter(expr string) (f Filter, err error) {
+
+	for _, term := range strings.Fields(expr) {
+
+		kv := strings.SplitN(term, "=", 2)
+		if len(kv) == 1 {
+			f.addTerm(term)
+			continue
+		}
+
+		values := splitList(kv[1])
+
+		switch strings.ToLower(kv[0]) {
+		case "team", "teams", "t":
+			f.Teams = append(f.Teams, values...)
+		case "league", "l":
+			f.Leagues = append(f.Leagues, values...)
+		case "division", "div", "d":
+			f.Divisions = append(f.Divisions, values...)
+		case "state", "s":
+			for _, v := range values {
+				if !isFilterState(v) {
+					return f, fmt.Errorf("unknown state %s, use %s, %s or %s", v, StateLive, StateFinal, StateUpcoming)
+				}
+				f.States = append(f.States, strings.ToLower(v))
+			}
+		case "streams", "stream":
+			f.HasStream = true
+		default:
+			return f, fmt.Errorf("unknown filter %s", kv[0])
+		}
+	}
+
+	return
+}
+
+func (f *Filter) addTerm(term string) {
+
+	upper := strings.ToUpper(term)
+
+	switch {
+	case isFilterState(term):
+		f.States = append(f.States, strings.ToLower(term))
+	case strings.EqualFold(term, "streams"):
+		f.HasStream = true
+	case upper == "AL" || upper == "NL":
+		f.Leagues = append(f.Leagues, upper)
+	case len(upper) == 3 && (strings.HasPrefix(upper, "AL") || strings.HasPrefix(upper, "NL")) && strings.ContainsAny(upper[2:], "ECW"):
+		f.Divisions = append(f.Divisions, upper)
+	default:
+		f.Teams = append(f.Teams, splitList(term)...)
+	}
+}
+
+func isFilterState(s string) bool {
+	switch strings.ToLower(s) {
+	case StateLive, StateFinal, StateUpcoming:
+		return true
+	}
+	return false
+}
+
+// splitList splits a comma separated list, dropping empty values
+func splitList(s string) (list []string) {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return
+}
+
+// CheckLevel returns an error when filtering on leagues or divisions at a
+// level below MLB, where they're unknown
+func (f *Filter) CheckLevel(l Level) error {
+
+	if (len(f.Leagues) > 0 || len(f.Divisions) > 0) && !l.IsMLB() {
+		return fmt.Errorf("league and division filters are only available for MLB, not %s", l.Name)
+	}
+
+	return nil
+}
+
+// IsEmpty is true when the filter shows every game
+func (f *Filter) IsEmpty() bool {
+	return len(f.Teams) == 0 && len(f.Leagues) == 0 && len(f.Divisions) == 0 && len(f.States) == 0 && !f.HasStream
+}
+
+// Match is true when the game passes the filter
+func (f *Filter) Match(g *Game, hasStream bool) bool {
+
+	if f.HasStream && !hasStream {
+		return false
+	}
+
+	if len(f.Teams) > 0 && !matchAny(f.Teams, func(v string) bool {
+		return g.Teams.Away.Team.Matches(v) || g.Teams.Home.Team.Matches(v)
+	}) {
+		return false
+	}
+
+	if len(f.Leagues) > 0 && !matchAny(f.Leagues, func(v string) bool {
+		return g.Teams.Away.Team.inLeague(v) || g.Teams.Home.Team.inLeague(v)
+	}) {
+		return false
+	}
+
+	if len(f.Divisions) > 0 && !matchAny(f.Divisions, func(v string) bool {
+		return g.Teams.Away.Team.inDivision(v) || g.Teams.Home.Team.inDivision(v)
+	}) {
+		return false
+	}
+
+	if len(f.States) > 0 && !matchAny(f.States, func(v string) bool {
+		return gameState(g) == v
+	}) {
+		return false
+	}
+
+	return true
+}
+
+func matchAny(values []string, match func(string) bool) bool {
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// gameState returns whether the game is live, final or upcoming
+func gameState(g *Game) string {
+	switch {
+	case isCompleteGame(g.GameStatus.DetailedState):
+		return StateFinal
+	case hasGameStarted(g.GameStatus.DetailedState):
+		return StateLive
+	}
+	return StateUpcoming
+}
+
+// String returns the filter as an expression
+func (f *Filter) String() string {
+
+	var terms []string
+
+	for _, t := range []struct {
+		key    string
+		values []string
+	}{{"team", f.Teams}, {"league", f.Leagues}, {"division", f.Divisions}, {"state", f.States}} {
+		if len(t.values) > 0 {
+			terms = append(terms, t.key+"="+strings.Join(t.values, ","))
+		}
+	}
+
+	if f.HasStream {
+		terms = append(terms, "streams")
+	}
+
+	return strings.Join(terms, " ")
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
 	log "github.com/sirupsen/logrus"
@@ -65,7 +67,7 @@ func (gs *GameStreams) getPlaylistURL(url string) (playlist string, err error) {
 
 }
 
-func (gs *GameStreams) findGameStreams(g Game, ch chan *Stream, wg *sync.WaitGroup) {
+func (gs *GameStreams) findGameStreams(g Game, date string, ch chan *Stream, wg *sync.WaitGroup) {
 
 	cdns := [2]string{"akc", "l3c"}
 
@@ -89,7 +91,7 @@ func (gs *GameStreams) findGameStreams(g Game, ch chan *Stream, wg *sync.WaitGro
 				playlist := ""
 
 				for _, cdn := range cdns {
-					streamURL := fmt.Sprintf(gs.config.StreamPlaylistURL, gs.schedule.Date, strconv.Itoa(item.ID), cdn)
+					streamURL := fmt.Sprintf(gs.config.StreamPlaylistURL, date, strconv.Itoa(item.ID), cdn)
 					playlist, _ = gs.getPlaylistURL(streamURL)
 					if playlist != "" {
 						break
@@ -125,17 +127,20 @@ func (gs *GameStreams) findGameStreams(g Game, ch chan *Stream, wg *sync.WaitGro
 
 }
 
-// GetAvailableStreams check for available game streams
-func (gs *GameStreams) GetAvailableStreams() {
+// CheckStreams finds the available streams of the schedule's games. It
+// doesn't change the streams, so it can run while they're in use.
+func (gs *GameStreams) CheckStreams(s *Schedule) (found map[int]map[string]*Stream) {
 
 	var wg sync.WaitGroup
 	ch := make(chan *Stream)
 
 	log.Debug("Checking for game streams")
 
-	for _, g := range *gs.schedule.Games {
-		wg.Add(1)
-		go gs.findGameStreams(g, ch, &wg)
+	for _, d := range s.Dates {
+		for _, g := range d.Games {
+			wg.Add(1)
+			go gs.findGameStreams(g, d.Date, ch, &wg)
+		}
 	}
 
 	go func() {
@@ -143,15 +148,114 @@ func (gs *GameStreams) GetAvailableStreams() {
 		close(ch)
 	}()
 
+	found = make(map[int]map[string]*Stream)
 	for v := range ch {
-		if gs.Streams[v.GamePk] == nil {
-			gs.Streams[v.GamePk] = make(map[string]*Stream)
+		if found[v.GamePk] == nil {
+			found[v.GamePk] = make(map[string]*Stream)
 		}
-		gs.Streams[v.GamePk][v.ID] = v
+		found[v.GamePk][v.ID] = v
 	}
 
 	log.WithFields(log.Fields{
-		"streamCnt": len(gs.Streams),
+		"streamCnt": len(found),
 	}).Debug("Finished checking streams")
 
+	return
+}
+
+// SetStreams replaces the streams with those found. The map is changed in
+// place since the UI shares it.
+func (gs *GameStreams) SetStreams(found map[int]map[string]*Stream) {
+
+	// drop streams of games no longer on the schedule (e.g. after a date change)
+	for pk := range gs.Streams {
+		if _, ok := found[pk]; !ok {
+			delete(gs.Streams, pk)
+		}
+	}
+
+	for pk, s := range found {
+		gs.Streams[pk] = s
+	}
+}
+
+// Copy returns the streams found by game. The streams of a game are
+// replaced, not changed, when checked again so they aren't copied.
+func (gs *GameStreams) Copy() map[int]map[string]*Stream {
+
+	c := make(map[int]map[string]*Stream, len(gs.Streams))
+	for pk, s := range gs.Streams {
+		c[pk] = s
+	}
+
+	return c
+}
+
+// FindStreams finds streams by ID, call letters or team abbreviation. For a team
+// the team's own broadcast is preferred. A gameNumber greater than zero picks
+// the game of a doubleheader.
+func (gs *GameStreams) FindStreams(key string, gameNumber int) (streams []*Stream) {
+
+	pks := gs.streamGames(gameNumber)
+
+	for _, pk := range pks {
+		for _, s := range gs.Streams[pk] {
+			if s.ID == key || strings.EqualFold(s.CallLetters, key) {
+				streams = append(streams, s)
+			}
+		}
+	}
+
+	// fall back to matching the team
+	if len(streams) == 0 {
+		for _, pk := range pks {
+
+			g := gs.schedule.GameMap[pk]
+
+			feed := ""
+			if g.Teams.Home.Team.Matches(key) {
+				feed = "HOME"
+			} else if g.Teams.Away.Team.Matches(key) {
+				feed = "AWAY"
+			} else {
+				continue
+			}
+
+			var own, other []*Stream
+			for _, s := range gs.Streams[pk] {
+				if s.MediaFeedType == feed {
+					own = append(own, s)
+				} else {
+					other = append(other, s)
+				}
+			}
+
+			if len(own) > 0 {
+				streams = append(streams, own...)
+			} else {
+				streams = append(streams, other...)
+			}
+		}
+	}
+
+	sort.Slice(streams, func(i, j int) bool {
+		return streams[i].ID < streams[j].ID
+	})
+
+	return
+}
+
+// streamGames returns the games with streams in a stable order
+func (gs *GameStreams) streamGames(gameNumber int) (pks []int) {
+
+	for pk := range gs.Streams {
+		if gameNumber > 0 && gs.schedule.GameMap[pk].GameNumber != gameNumber {
+			continue
+		}
+		pks = append(pks, pk)
+	}
+
+	sort.Ints(pks)
+
+	return
 }
@@ -0,0 +1,72 @@
+package lib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Level is a level of play in the stats API
+type Level struct {
+	Name     string
+	SportID  int
+	GameType string
+}
+
+// Levels available by name
+var Levels = map[string]Level{
+	"mlb":      {"MLB", 1, ""},
+	"aaa":      {"Triple-A", 11, ""},
+	"aa":       {"Double-A", 12, ""},
+	"high-a":   {"High-A", 13, ""},
+	"single-a": {"Single-A", 14, ""},
+	"rookie":   {"Rookie", 16, ""},
+	"spring":   {"Spring Training", 1, "S"},
+	"wbc":      {"World Baseball Classic", 51, ""},
+}
+
+// LevelNames returns the names of the available levels
+func LevelNames() string {
+	return "mlb, aaa, aa, high-a, single-a, rookie, spring, wbc"
+}
+
+// SetLevel sets the level of play of the schedule and team requests
+func (c *Config) SetLevel(name string) error {
+
+	if _, ok := Levels[strings.ToLower(name)]; !ok {
+		return fmt.Errorf("unknown level %s, use one of %s", name, LevelNames())
+	}
+
+	c.Level = strings.ToLower(name)
+
+	return nil
+}
+
+// GetLevel returns the level of play set, or the zero Level when none is set
+// so the configured URLs are used as they are
+func (c *Config) GetLevel() Level {
+	return Levels[c.Level]
+}
+
+// IsMLB is true for levels played by MLB teams
+func (l Level) IsMLB() bool {
+	return l.SportID <= 1
+}
+
+// HasStandings is true for levels with division and wild card standings
+func (l Level) HasStandings() bool {
+	return l.IsMLB() && l.GameType == ""
+}
+
+// params are the query parameters requesting the level's schedule
+func (l Level) params() map[string]string {
+
+	if l.SportID == 0 {
+		return map[string]string{}
+	}
+
+	return map[string]string{
+		"sportId":  strconv.Itoa(l.SportID),
+		"gameType": l.GameType,
+	}
+}
@@ -0,0 +1,39 @@
+package lib
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSetLevelTwiceKeepsHydrations(t *testing.T) {
+
+	c := Config{StatsURL: "https://statsapi.mlb.com/api/v1/schedule?sportId=1&date=%s&hydrate=team,linescore&language=en"}
+
+	for _, name := range []string{"aaa", "spring"} {
+		if err := c.SetLevel(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	u, err := scheduleURL(c.StatsURL, c.GetLevel(), "2021-03-01", "2021-03-01", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := parsed.Query()
+	if !strings.Contains(q.Get("hydrate"), "team,linescore") {
+		t.Errorf("hydrate = %q, want team,linescore kept", q.Get("hydrate"))
+	}
+	if q.Get("sportId") != "1" || q.Get("gameType") != "S" {
+		t.Errorf("sportId = %q gameType = %q, want 1 and S", q.Get("sportId"), q.Get("gameType"))
+	}
+	if q.Get("date") != "2021-03-01" {
+		t.Errorf("date = %q, want 2021-03-01", q.Get("date"))
+	}
+}
@@ -0,0 +1,228 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// FollowRate is how often the live feed is polled
+	FollowRate = 15 * time.Second
+	// followBacklog is the number of already completed plays shown when following starts
+	followBacklog = 3
+)
+
+// Play is a completed or in progress plate appearance
+type Play struct {
+	Result struct {
+		Event       string `json:"event"`
+		Description string `json:"description"`
+		AwayScore   int    `json:"awayScore"`
+		HomeScore   int    `json:"homeScore"`
+	} `json:"result"`
+	About struct {
+		AtBatIndex    int    `json:"atBatIndex"`
+		HalfInning    string `json:"halfInning"`
+		Inning        int    `json:"inning"`
+		IsComplete    bool   `json:"isComplete"`
+		IsScoringPlay bool   `json:"isScoringPlay"`
+	} `json:"about"`
+	Count struct {
+		Balls   int `json:"balls"`
+		Strikes int `json:"strikes"`
+		Outs    int `json:"outs"`
+	} `json:"count"`
+	Matchup struct {
+		Batter       Person  `json:"batter"`
+		Pitcher      Person  `json:"pitcher"`
+		PostOnFirst  *Person `json:"postOnFirst"`
+		PostOnSecond *Person `json:"postOnSecond"`
+		PostOnThird  *Person `json:"postOnThird"`
+	} `json:"matchup"`
+}
+
+// LiveFeed contains the plays of a game
+type LiveFeed struct {
+	GameData struct {
+		Status struct {
+			DetailedState string `json:"detailedState"`
+		} `json:"status"`
+	} `json:"gameData"`
+	LiveData struct {
+		Plays struct {
+			AllPlays []Play `json:"allPlays"`
+		} `json:"plays"`
+	} `json:"liveData"`
+}
+
+// GetLiveFeed gets the live feed of a game
+func GetLiveFeed(url string, gamePk int) (f LiveFeed, err error) {
+
+	resp, err := httpGet(fmt.Sprintf(url, gamePk))
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&f)
+
+	return
+}
+
+// Follower prints the plays of a game as they happen
+type Follower struct {
+	config *Config
+	ui     *UI
+	// mu guards the game followed while it's started and stopped
+	mu      sync.Mutex
+	stop    chan bool
+	game    Game
+	running bool
+	Output  io.Writer
+}
+
+// NewFollower creates a Follower
+func NewFollower(c *Config, ui *UI) (f Follower) {
+	f.config = c
+	f.ui = ui
+	f.Output = os.Stdout
+	return
+}
+
+// Start follows the game, stopping the game followed before
+func (f *Follower) Start(g Game) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stopFollowing()
+
+	f.stop = make(chan bool)
+	f.game = g
+	f.running = true
+
+	go f.run(g, f.stop)
+}
+
+// Following returns the game followed, if any
+func (f *Follower) Following() (g Game, ok bool) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.game, f.running
+}
+
+// run polls the live feed of the game until it's over or stop is closed
+func (f *Follower) run(g Game, stop chan bool) {
+
+	log.WithFields(log.Fields{
+		"gamePk": g.GamePk,
+	}).Debug("Following game")
+
+	last := -1
+	away, home := g.LineScore.Scoring.Away.Runs, g.LineScore.Scoring.Home.Runs
+
+	poll := func() (over bool) {
+
+		feed, err := GetLiveFeed(f.config.LiveFeedURL, g.GamePk)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"gamePk": g.GamePk,
+				"err":    err,
+			}).Debug("Unable to get live feed")
+			return
+		}
+
+		// don't print plays of a game no longer followed
+		select {
+		case <-stop:
+			return true
+		default:
+		}
+
+		plays := feed.LiveData.Plays.AllPlays
+
+		// only show the most recent plays when starting to follow
+		if last == -1 && len(plays) > followBacklog {
+			p := plays[len(plays)-followBacklog-1]
+			last = p.About.AtBatIndex
+			away, home = p.Result.AwayScore, p.Result.HomeScore
+		}
+
+		for i := range plays {
+			p := &plays[i]
+			if !p.About.IsComplete || p.About.AtBatIndex <= last {
+				continue
+			}
+
+			scored := p.Result.AwayScore != away || p.Result.HomeScore != home
+			away, home = p.Result.AwayScore, p.Result.HomeScore
+			last = p.About.AtBatIndex
+
+			fmt.Fprintln(f.Output, f.ui.GetPlayDisplay(&g, p, scored))
+		}
+
+		if isCompleteGame(feed.GameData.Status.DetailedState) {
+			fmt.Fprintln(f.Output, f.ui.getTeamDisplay(&g, true)+" is over.")
+			return true
+		}
+
+		return
+	}
+
+	if poll() {
+		f.finish(stop)
+		return
+	}
+
+	ticker := time.NewTicker(FollowRate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if poll() {
+				f.finish(stop)
+				return
+			}
+		}
+	}
+}
+
+// finish marks the game over unless another game has been followed since
+func (f *Follower) finish(stop chan bool) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.stop == stop {
+		f.running = false
+	}
+}
+
+// Stop following the game
+func (f *Follower) Stop() {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stopFollowing()
+}
+
+func (f *Follower) stopFollowing() {
+	if f.running {
+		close(f.stop)
+		f.running = false
+		log.Debug("Stopped following game")
+	}
+}
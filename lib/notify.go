@@ -0,0 +1,158 @@
+package lib
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unicode"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// notifyTitle is the title of notifications that have one
+const notifyTitle = "mlbme"
+
+// notification methods
+const (
+	NotifyNone   = "none"
+	NotifyAuto   = "auto"
+	NotifyBell   = "bell"
+	NotifyOSC9   = "osc9"
+	NotifyOSC777 = "osc777"
+	NotifySend   = "notify-send"
+)
+
+// CheckNotifyMethod returns an error for an unknown notification method
+func CheckNotifyMethod(method string) error {
+	switch method {
+	case "", NotifyNone, NotifyAuto, NotifyBell, NotifyOSC9, NotifyOSC777, NotifySend:
+		return nil
+	}
+	return fmt.Errorf("notify %s is not one of %s, %s, %s, %s, %s or %s", method,
+		NotifyNone, NotifyAuto, NotifyBell, NotifyOSC9, NotifyOSC777, NotifySend)
+}
+
+// Notifier alerts on events of favorite teams and the game followed
+type Notifier struct {
+	method string
+	Output io.Writer
+}
+
+// NewNotifier creates a Notifier. Auto uses notify-send when installed and
+// otherwise the terminal bell. The bell and escape sequences are only
+// written to a terminal.
+func NewNotifier(method string) (n Notifier) {
+
+	n.Output = os.Stdout
+
+	if method == NotifyAuto {
+		method = NotifyBell
+		if _, err := exec.LookPath(NotifySend); err == nil {
+			method = NotifySend
+		}
+	}
+
+	switch method {
+	case NotifyBell, NotifyOSC9, NotifyOSC777:
+		if !IsTerminal(os.Stdout) {
+			method = NotifyNone
+		}
+	case "":
+		method = NotifyNone
+	}
+
+	log.WithFields(log.Fields{
+		"method": method,
+	}).Debug("Notifications")
+
+	n.method = method
+
+	return
+}
+
+// Notify sends the message
+func (n *Notifier) Notify(message string) {
+
+	switch n.method {
+	case NotifyBell:
+		fmt.Fprint(n.Output, "\a")
+	case NotifyOSC9:
+		fmt.Fprint(n.Output, "\x1b]9;"+oscText(message, false)+"\a")
+	case NotifyOSC777:
+		fmt.Fprint(n.Output, "\x1b]777;notify;"+oscText(notifyTitle, true)+";"+oscText(message, true)+"\a")
+	case NotifySend:
+		// don't hold up the refresh
+		go func() {
+			if err := exec.Command(NotifySend, notifyTitle, message).Run(); err != nil {
+				log.WithFields(log.Fields{
+					"err": err,
+				}).Debug("Unable to notify")
+			}
+		}()
+	}
+}
+
+// oscText drops control characters, which could end the escape sequence
+// early, and for OSC 777 the semicolons separating its title and body
+func oscText(s string, semicolons bool) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || (semicolons && r == ';') {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// GetNotifications builds the messages for the events of favorite teams and
+// the game followed: their runs, starts, extra innings, close late innings and
+// no-hitters. Runs scored by the other team only count for the game followed.
+func (ui *UI) GetNotifications(events []Event, followed int) (messages []string) {
+
+	for _, e := range events {
+
+		g, ok := ui.schedule.GameMap[e.GamePk]
+		if !ok {
+			continue
+		}
+
+		away, home := &g.Teams.Away.Team, &g.Teams.Home.Team
+		if g.GamePk != followed && !ui.isFavorite(away) && !ui.isFavorite(home) {
+			continue
+		}
+
+		matchup := away.Abbreviation + " @ " + home.Abbreviation
+
+		switch e.Type {
+		case EventRunScored:
+			scorer := away
+			if home.Abbreviation == e.Team {
+				scorer = home
+			}
+			if g.GamePk == followed || ui.isFavorite(scorer) {
+				messages = append(messages, e.Team+" scored: "+ui.getNotifyScore(&g))
+			}
+		case EventGameStarted:
+			messages = append(messages, matchup+" started")
+		case EventExtraInnings:
+			messages = append(messages, matchup+" goes to extra innings: "+ui.getNotifyScore(&g))
+		case EventCloseGame:
+			messages = append(messages, "Close game: "+ui.getNotifyScore(&g))
+		case EventNoHitter:
+			messages = append(messages, e.Team+" no-hitter: "+ui.getNotifyScore(&g))
+		}
+	}
+
+	return
+}
+
+// getNotifyScore shows the score and inning on one line, e.g. NYM 3 @ ATL 2, Bot 8th
+func (ui *UI) getNotifyScore(g *Game) string {
+
+	away, home := g.LineScore.Scoring.Away, g.LineScore.Scoring.Home
+
+	return g.Teams.Away.Team.Abbreviation + " " + strconv.Itoa(away.Runs) + " @ " +
+		g.Teams.Home.Team.Abbreviation + " " + strconv.Itoa(home.Runs) + ", " + ui.getInningDisplay(g)
+}
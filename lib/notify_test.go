@@ -0,0 +1,71 @@
+package lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOSCText(t *testing.T) {
+
+	tests := []struct {
+		in         string
+		semicolons bool
+		want       string
+	}{
+		{"NYM scored: NYM 3 @ ATL 2", false, "NYM scored: NYM 3 @ ATL 2"},
+		{"bell\a and \x1b]escape", false, "bell and ]escape"},
+		{"title;body", false, "title;body"},
+		{"title;body", true, "titlebody"},
+	}
+
+	for _, tt := range tests {
+		if got := oscText(tt.in, tt.semicolons); got != tt.want {
+			t.Errorf("oscText(%q, %v) = %q, want %q", tt.in, tt.semicolons, got, tt.want)
+		}
+	}
+}
+
+func TestGetNotifications(t *testing.T) {
+
+	game := func(pk int, away, home string) Game {
+		g := testGame("In Progress", 8, "Top", 2, 2)
+		g.GamePk = pk
+		g.LineScore.CurrentInningOrdinal = "8th"
+		g.Teams.Away.Team.Abbreviation = away
+		g.Teams.Home.Team.Abbreviation = home
+		return g
+	}
+
+	s := testSchedule("2021-06-01", game(1, "NYM", "ATL"), game(2, "NYY", "BOS"), game(3, "SF", "LAD"))
+	ui := NewUI(&Config{Favorites: []string{"NYM"}}, s, nil, &Filter{})
+
+	events := []Event{
+		{Type: EventRunScored, GamePk: 1, Team: "NYM"},
+		{Type: EventRunScored, GamePk: 1, Team: "ATL"},
+		{Type: EventRunScored, GamePk: 2, Team: "NYY"},
+		{Type: EventRunScored, GamePk: 3, Team: "LAD"},
+		{Type: EventGameStarted, GamePk: 1},
+		{Type: EventGameStarted, GamePk: 2},
+		{Type: EventInningChanged, GamePk: 1},
+		{Type: EventExtraInnings, GamePk: 1},
+		{Type: EventCloseGame, GamePk: 3},
+		{Type: EventNoHitter, GamePk: 2, Team: "NYY"},
+		{Type: EventNoHitter, GamePk: 1, Team: "ATL"},
+	}
+
+	// game 3 is followed, NYM is the favorite team
+	got := ui.GetNotifications(events, 3)
+
+	want := []string{
+		"NYM scored: NYM 2 @ ATL 2, Top 8th",
+		"LAD scored: SF 2 @ LAD 2, Top 8th",
+		"NYM @ ATL started",
+		"NYM @ ATL goes to extra innings: NYM 2 @ ATL 2, Top 8th",
+		"Close game: SF 2 @ LAD 2, Top 8th",
+		"ATL no-hitter: NYM 2 @ ATL 2, Top 8th",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
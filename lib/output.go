@@ -0,0 +1,239 @@
+package lib
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// output formats
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputCSV   = "csv"
+	OutputTSV   = "tsv"
+)
+
+// ScheduleOutput is the machine-readable form of the scoreboard
+type ScheduleOutput struct {
+	StartDate     string       `json:"startDate"`
+	EndDate       string       `json:"endDate"`
+	LastRefreshed string       `json:"lastRefreshed"`
+	Games         []GameOutput `json:"games"`
+}
+
+// GameOutput is the machine-readable form of a game. Scores and the inning
+// are null before the game starts or while hidden to avoid spoilers.
+type GameOutput struct {
+	GamePk            int                 `json:"gamePk"`
+	Date              string              `json:"date"`
+	StartTime         string              `json:"startTime"`
+	State             string              `json:"state"`
+	Status            string              `json:"status"`
+	Inning            *string             `json:"inning"`
+	GameNumber        int                 `json:"gameNumber"`
+	SeriesDescription string              `json:"seriesDescription"`
+	SeriesGameNumber  int                 `json:"seriesGameNumber"`
+	GamesInSeries     int                 `json:"gamesInSeries"`
+	SeriesStatus      *SeriesStatusOutput `json:"seriesStatus"`
+	Venue             string              `json:"venue"`
+	Away              TeamOutput          `json:"away"`
+	Home              TeamOutput          `json:"home"`
+	Streams           []StreamOutput      `json:"streams"`
+}
+
+// SeriesStatusOutput is the machine-readable standing of a series, e.g. ALCS
+// with the result NYY leads 2-0
+type SeriesStatusOutput struct {
+	ShortName string `json:"shortName"`
+	Result    string `json:"result"`
+	IsOver    bool   `json:"isOver"`
+}
+
+// TeamOutput is the machine-readable form of a team playing in a game
+type TeamOutput struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	Abbreviation    string `json:"abbreviation"`
+	Runs            *int   `json:"runs"`
+	Hits            *int   `json:"hits"`
+	Errors          *int   `json:"errors"`
+	ProbablePitcher string `json:"probablePitcher"`
+}
+
+// StreamOutput is the machine-readable form of a game's stream
+type StreamOutput struct {
+	GamePk      int    `json:"gamePk"`
+	ID          string `json:"id"`
+	FeedType    string `json:"feedType"`
+	CallLetters string `json:"callLetters"`
+}
+
+// CheckOutputFormat returns an error for formats other than table, json, csv and tsv
+func CheckOutputFormat(format string) error {
+	switch format {
+	case OutputTable, OutputJSON, OutputCSV, OutputTSV:
+		return nil
+	}
+	return fmt.Errorf("output format %s is not one of %s, %s, %s or %s", format, OutputTable, OutputJSON, OutputCSV, OutputTSV)
+}
+
+// GenerateOutput builds the scoreboard in the format
+func (ui *UI) GenerateOutput(format string) (string, error) {
+
+	if err := CheckOutputFormat(format); err != nil {
+		return "", err
+	}
+
+	switch format {
+	case OutputJSON:
+		b, err := json.MarshalIndent(ui.GetScheduleOutput(), "", "  ")
+		return string(b) + nl, err
+	case OutputCSV:
+		return ui.generateDelimited(',')
+	case OutputTSV:
+		return ui.generateDelimited('\t')
+	}
+
+	return ui.GenerateScoreboard(), nil
+}
+
+// GetScheduleOutput converts the games shown on the scoreboard to their machine-readable form
+func (ui *UI) GetScheduleOutput() (so ScheduleOutput) {
+
+	so.StartDate = ui.schedule.Date
+	so.EndDate = ui.schedule.EndDate
+	so.LastRefreshed = ui.schedule.LastRefreshed.Format(time.RFC3339)
+	so.Games = []GameOutput{}
+
+	for _, d := range ui.schedule.Dates {
+		for _, g := range ui.pinFavorites(d.Games) {
+			if ui.showGame(&g) {
+				so.Games = append(so.Games, ui.getGameOutput(&g, d.Date))
+			}
+		}
+	}
+
+	return
+}
+
+func (ui *UI) getGameOutput(g *Game, date string) (o GameOutput) {
+
+	o.GamePk = g.GamePk
+	o.Date = date
+	o.StartTime = g.GameDate
+	o.State = gameState(g)
+	o.Status = g.GameStatus.DetailedState
+	o.GameNumber = g.GameNumber
+	o.Venue = g.Venue.Name
+	o.Streams = []StreamOutput{}
+
+	o.SeriesDescription = g.SeriesDescription
+	o.SeriesGameNumber = g.SeriesGameNumber
+	o.GamesInSeries = g.GamesInSeries
+
+	// the standing gives away earlier games of the series
+	if g.SeriesStatus != nil && !ui.hideResults(g) {
+		o.SeriesStatus = &SeriesStatusOutput{ShortName: g.SeriesStatus.ShortName, Result: g.SeriesStatus.Result, IsOver: g.SeriesStatus.IsOver}
+	}
+
+	o.Away = getTeamOutput(&g.Teams.Away)
+	o.Home = getTeamOutput(&g.Teams.Home)
+
+	if ui.hideSpoilers(g) {
+		o.Status = ui.getSpoilerFreeStatus(g)
+	} else if hasGameStarted(g.GameStatus.DetailedState) {
+		away, home := g.LineScore.Scoring.Away, g.LineScore.Scoring.Home
+		o.Away.Runs, o.Away.Hits, o.Away.Errors = &away.Runs, &away.Hits, &away.Errors
+		o.Home.Runs, o.Home.Hits, o.Home.Errors = &home.Runs, &home.Hits, &home.Errors
+		if isActiveGame(g.GameStatus.DetailedState) {
+			inning := ui.getInningDisplay(g)
+			o.Inning = &inning
+		}
+	}
+
+	for _, s := range ui.streams[g.GamePk] {
+		o.Streams = append(o.Streams, getStreamOutput(s))
+	}
+
+	sort.Slice(o.Streams, func(i, j int) bool {
+		return o.Streams[i].ID < o.Streams[j].ID
+	})
+
+	return
+}
+
+// GetGameOutput converts a game to its machine-readable form
+func (ui *UI) GetGameOutput(gamePk int) (o GameOutput, ok bool) {
+
+	for _, d := range ui.schedule.Dates {
+		for _, g := range d.Games {
+			if g.GamePk == gamePk {
+				return ui.getGameOutput(&g, d.Date), true
+			}
+		}
+	}
+
+	return
+}
+
+func getStreamOutput(s *Stream) StreamOutput {
+	return StreamOutput{GamePk: s.GamePk, ID: s.ID, FeedType: s.MediaFeedType, CallLetters: s.CallLetters}
+}
+
+func getTeamOutput(gt *GameTeam) (o TeamOutput) {
+
+	o.ID = gt.Team.ID
+	o.Name = gt.Team.Name
+	o.Abbreviation = gt.Team.Abbreviation
+
+	if gt.ProbablePitcher != nil {
+		o.ProbablePitcher = gt.ProbablePitcher.FullName
+	}
+
+	return
+}
+
+// generateDelimited builds a row for each game with a header row
+func (ui *UI) generateDelimited(comma rune) (string, error) {
+
+	sb := &strings.Builder{}
+	w := csv.NewWriter(sb)
+	w.Comma = comma
+
+	w.Write([]string{"date", "gamePk", "startTime", "state", "status", "inning", "gameNumber",
+		"away", "awayRuns", "awayHits", "awayErrors", "home", "homeRuns", "homeHits", "homeErrors", "streams"})
+
+	optional := func(v *int) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	}
+
+	for _, g := range ui.GetScheduleOutput().Games {
+
+		inning := ""
+		if g.Inning != nil {
+			inning = *g.Inning
+		}
+
+		var streams []string
+		for _, s := range g.Streams {
+			streams = append(streams, s.CallLetters)
+		}
+
+		w.Write([]string{g.Date, strconv.Itoa(g.GamePk), g.StartTime, g.State, g.Status, inning, strconv.Itoa(g.GameNumber),
+			g.Away.Abbreviation, optional(g.Away.Runs), optional(g.Away.Hits), optional(g.Away.Errors),
+			g.Home.Abbreviation, optional(g.Home.Runs), optional(g.Home.Hits), optional(g.Home.Errors),
+			strings.Join(streams, " ")})
+	}
+
+	w.Flush()
+
+	return sb.String(), w.Error()
+}
@@ -3,6 +3,8 @@ package lib
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -10,7 +12,10 @@ import (
 
 // Score represents line score for team
 type Score struct {
-	Runs int `json:"runs"`
+	Runs       int `json:"runs"`
+	Hits       int `json:"hits"`
+	Errors     int `json:"errors"`
+	LeftOnBase int `json:"leftOnBase"`
 }
 
 // Scoring holds Home and Away team line score
@@ -19,27 +24,114 @@ type Scoring struct {
 	Away Score `json:"away"`
 }
 
+// InningScore represents a team's line score for an inning. Runs is nil
+// when the team hasn't batted in the inning.
+type InningScore struct {
+	Runs       *int `json:"runs"`
+	Hits       int  `json:"hits"`
+	Errors     int  `json:"errors"`
+	LeftOnBase int  `json:"leftOnBase"`
+}
+
+// Inning holds Home and Away team line score for an inning
+type Inning struct {
+	Num        int         `json:"num"`
+	OrdinalNum string      `json:"ordinalNum"`
+	Home       InningScore `json:"home"`
+	Away       InningScore `json:"away"`
+}
+
 // LineScore contains information about the current state of the game
 type LineScore struct {
-	CurrentInningOrdinal string  `json:"currentInningOrdinal"`
-	InningState          string  `json:"inningState"`
-	Scoring              Scoring `json:"teams"`
+	CurrentInning        int      `json:"currentInning"`
+	CurrentInningOrdinal string   `json:"currentInningOrdinal"`
+	InningState          string   `json:"inningState"`
+	ScheduledInnings     int      `json:"scheduledInnings"`
+	Innings              []Inning `json:"innings"`
+	Scoring              Scoring  `json:"teams"`
+	Balls                int      `json:"balls"`
+	Strikes              int      `json:"strikes"`
+	Outs                 int      `json:"outs"`
+	Offense              struct {
+		Batter Person  `json:"batter"`
+		First  *Person `json:"first"`
+		Second *Person `json:"second"`
+		Third  *Person `json:"third"`
+	} `json:"offense"`
+	Defense struct {
+		Pitcher Person `json:"pitcher"`
+	} `json:"defense"`
 }
 
 // Team details
 type Team struct {
-	Name         string `json:"teamName"`
-	Abbreviation string `json:"abbreviation"`
+	ID            int    `json:"id"`
+	Name          string `json:"teamName"`
+	Abbreviation  string `json:"abbreviation"`
+	TeamCode      string `json:"teamCode"`
+	ParentOrgName string `json:"parentOrgName"`
+	League        struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"league"`
+	Division struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"division"`
+}
+
+// Matches is true when the key is the team's abbreviation, code or name, or
+// for minor league teams the name of their parent club
+func (t *Team) Matches(key string) bool {
+	for _, v := range []string{t.Abbreviation, t.TeamCode, t.Name, t.ParentOrgName} {
+		if v != "" && strings.EqualFold(v, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// inLeague is true when the key is the abbreviation or name of the team's league
+func (t *Team) inLeague(key string) bool {
+	return strings.EqualFold(leagues[t.League.ID], key) || (t.League.Name != "" && strings.EqualFold(t.League.Name, key))
+}
+
+// inDivision is true when the key is the abbreviation or name of the team's division
+func (t *Team) inDivision(key string) bool {
+	return strings.EqualFold(divisions[t.Division.ID], key) || (t.Division.Name != "" && strings.EqualFold(t.Division.Name, key))
+}
+
+// normalize fills in details missing for some minor league teams
+func (t *Team) normalize() {
+	if t.Abbreviation == "" {
+		t.Abbreviation = strings.ToUpper(t.TeamCode)
+	}
+}
+
+// GameTeam is a team playing in a game
+type GameTeam struct {
+	Team            Team    `json:"team"`
+	ProbablePitcher *Person `json:"probablePitcher"`
 }
 
 // Teams contains the teams playing
 type Teams struct {
-	Away struct {
-		Team Team `json:"team"`
-	} `json:"away"`
-	Home struct {
-		Team Team `json:"team"`
-	} `json:"home"`
+	Away GameTeam `json:"away"`
+	Home GameTeam `json:"home"`
+}
+
+// Broadcast is a TV or radio broadcast of a game
+type Broadcast struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	HomeAway string `json:"homeAway"`
+}
+
+// SeriesStatus is the state of a series, e.g. NYY leads 2-0
+type SeriesStatus struct {
+	ShortName string `json:"shortName"`
+	Result    string `json:"result"`
+	IsOver    bool   `json:"isOver"`
 }
 
 // MediaItem contains media available for the game
@@ -58,8 +150,19 @@ type Game struct {
 		DetailedState string `json:"detailedState"`
 		StatusCode    string `json:"statusCode"`
 	} `json:"status"`
-	GameDate string `json:"gameDate"`
-	Content  struct {
+	GameDate          string        `json:"gameDate"`
+	GameType          string        `json:"gameType"`
+	DoubleHeader      string        `json:"doubleHeader"`
+	GameNumber        int           `json:"gameNumber"`
+	SeriesDescription string        `json:"seriesDescription"`
+	SeriesGameNumber  int           `json:"seriesGameNumber"`
+	GamesInSeries     int           `json:"gamesInSeries"`
+	SeriesStatus      *SeriesStatus `json:"seriesStatus"`
+	Venue             struct {
+		Name string `json:"name"`
+	} `json:"venue"`
+	Broadcasts []Broadcast `json:"broadcasts"`
+	Content    struct {
 		Media struct {
 			EPG []struct {
 				Title      string      `json:"title"`
@@ -71,46 +174,139 @@ type Game struct {
 	LineScore LineScore `json:"linescore"`
 }
 
+// ScheduleDate contains the games played on a single day
+type ScheduleDate struct {
+	Date  string `json:"date"`
+	Games []Game `json:"games"`
+}
+
 // Data root of the JSON. Contains Dates array.
 type Data struct {
-	Dates []struct {
-		Games []Game `json:"games"`
-	} `json:"dates"`
-	TotalGames           int `json:"totalGames"`
-	TotalGamesInProgress int `json:"totalGamesInProgress"`
+	Dates                []ScheduleDate `json:"dates"`
+	TotalGames           int            `json:"totalGames"`
+	TotalGamesInProgress int            `json:"totalGamesInProgress"`
 }
 
-// Schedule contains details of the day's MLB games.
+// Schedule contains details of the MLB games for one or more days.
 type Schedule struct {
 	Date                 string
+	EndDate              string
 	URL                  string
 	TotalGames           *int
 	TotalGamesInProgress *int
 	TotalCompletedGames  int
 	CompletedGames       bool
 	InProgressGames      bool
+	Dates                []ScheduleDate
 	Games                *[]Game
 	GameMap              map[int]Game
 	LastRefreshed        time.Time
 }
 
-// GetMLBSchedule gets a day's schedule of games
-func GetMLBSchedule(url string) (s Schedule, err error) {
+// IsDoubleHeader is true for either game of a traditional or split doubleheader
+func (g *Game) IsDoubleHeader() bool {
+	return g.DoubleHeader == "Y" || g.DoubleHeader == "S"
+}
 
-	log.Debug("Getting MLB schedule")
+// FindGame finds a game by gamePk or the abbreviation of either team. The
+// abbreviation may be followed by a game number to pick a doubleheader game, e.g. NYY 2.
+func (s *Schedule) FindGame(key string) (g Game, ok bool) {
+
+	key, gameNumber := ParseGameKey(key)
+
+	if pk, err := strconv.Atoi(key); err == nil {
+		g, ok = s.GameMap[pk]
+		return
+	}
+
+	for _, g = range *s.Games {
+		if gameNumber > 0 && g.GameNumber != gameNumber {
+			continue
+		}
+		if g.Teams.Away.Team.Matches(key) || g.Teams.Home.Team.Matches(key) {
+			return g, true
+		}
+	}
+
+	return Game{}, false
+}
+
+// ParseGameKey splits an optional doubleheader game number from a team
+// abbreviation or call letters, e.g. YES 2
+func ParseGameKey(input string) (key string, gameNumber int) {
+
+	f := strings.Fields(input)
+	if len(f) == 2 {
+		if n, err := strconv.Atoi(f[1]); err == nil {
+			return f[0], n
+		}
+	}
+
+	return strings.TrimSpace(input), 0
+}
+
+// DateFormat is the layout of schedule dates
+const DateFormat = "2006-01-02"
+
+// DefaultScheduleDate returns the date of the current day's games
+func DefaultScheduleDate() time.Time {
 
 	// check for in progress games after midnight, but before 3AM
 	dt := time.Now()
 	if dt.Hour() <= 3 {
-		s.Date = time.Now().AddDate(0, 0, -1).Local().Format("2006-01-02")
-	} else {
-		s.Date = dt.Local().Format("2006-01-02")
+		dt = dt.AddDate(0, 0, -1)
 	}
 
-	s.LastRefreshed = dt
+	y, m, d := dt.Local().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// ParseDate parses a YYYY-MM-DD date in local time
+func ParseDate(value string) (time.Time, error) {
+	return time.ParseInLocation(DateFormat, value, time.Local)
+}
 
-	// we are only getting one day of data
-	s.URL = fmt.Sprintf(url, s.Date)
+// scheduleURL builds the schedule request of the level from start through end
+func scheduleURL(url string, level Level, start, end string, params map[string]string) (u string, err error) {
+
+	for k, v := range level.params() {
+		params[k] = v
+	}
+
+	if end != start {
+		params["date"] = ""
+		params["startDate"] = start
+		params["endDate"] = end
+	}
+
+	if u, err = setQuery(fmt.Sprintf(url, start), params); err != nil {
+		return
+	}
+
+	return addHydrations(u, "probablePitcher(stats(group=[pitching],type=[season]))", "venue", "broadcasts(all)", "seriesStatus")
+}
+
+// GetMLBSchedule gets the schedule of games at the level from start through end
+func GetMLBSchedule(url string, level Level, start, end time.Time) (s Schedule, err error) {
+	return getSchedule(url, level, start, end, map[string]string{})
+}
+
+// GetTeamSchedule gets the schedule of a team's games at the level from start through end
+func GetTeamSchedule(url string, level Level, teamID int, start, end time.Time) (s Schedule, err error) {
+	return getSchedule(url, level, start, end, map[string]string{"teamId": strconv.Itoa(teamID)})
+}
+
+func getSchedule(url string, level Level, start, end time.Time, params map[string]string) (s Schedule, err error) {
+
+	log.Debug("Getting MLB schedule")
+
+	s.Date = start.Format(DateFormat)
+	s.EndDate = end.Format(DateFormat)
+	s.LastRefreshed = time.Now()
+
+	if s.URL, err = scheduleURL(url, level, s.Date, s.EndDate, params); err != nil {
+		return
+	}
 
 	d := new(Data)
 
@@ -133,11 +329,27 @@ func GetMLBSchedule(url string) (s Schedule, err error) {
 		s.InProgressGames = true
 	}
 
-	if len(d.Dates) > 0 {
+	// the API omits days without games, keep every day in the range
+	dates := make(map[string]ScheduleDate)
+	for _, sd := range d.Dates {
+		dates[sd.Date] = sd
+	}
 
-		s.Games = &d.Dates[0].Games
+	games := []Game{}
 
-		for _, g := range *s.Games {
+	for dt := start; dt.Format(DateFormat) <= s.EndDate; dt = dt.AddDate(0, 0, 1) {
+
+		sd, ok := dates[dt.Format(DateFormat)]
+		if !ok {
+			sd = ScheduleDate{Date: dt.Format(DateFormat), Games: []Game{}}
+		}
+
+		for i := range sd.Games {
+			sd.Games[i].Teams.Away.Team.normalize()
+			sd.Games[i].Teams.Home.Team.normalize()
+		}
+
+		for _, g := range sd.Games {
 			s.GameMap[g.GamePk] = g
 			if isCompleteGame(g.GameStatus.DetailedState) {
 				s.CompletedGames = true
@@ -145,16 +357,20 @@ func GetMLBSchedule(url string) (s Schedule, err error) {
 			}
 		}
 
-	} else {
-		s.Games = &[]Game{}
+		games = append(games, sd.Games...)
+		s.Dates = append(s.Dates, sd)
 	}
 
+	s.Games = &games
+
 	log.WithFields(log.Fields{
 		"totalGames":           d.TotalGames,
 		"totalGamesInProgress": d.TotalGamesInProgress,
 		"totalCompletedGames":  s.TotalCompletedGames,
 		"completedGames":       s.CompletedGames,
 		"date":                 s.Date,
+		"endDate":              s.EndDate,
+		"days":                 len(s.Dates),
 		"lastRefreshed":        s.LastRefreshed,
 	}).Debug("scoreboard stats")
 
@@ -0,0 +1,293 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAddress is where the REST API listens unless set
+	DefaultAddress = "localhost:8080"
+	// keepAliveRate is how often an idle event stream is written to so proxies don't close it
+	keepAliveRate = 30 * time.Second
+)
+
+// Server exposes the scoreboard, streams and player over a REST API and
+// serves the web dashboard that uses it
+type Server struct {
+	ui          *UI
+	gamestreams *GameStreams
+	streamlink  *Streamlink
+	events      *EventStream
+	lock        *sync.RWMutex
+	httpStream  bool
+	// playing serializes starting and stopping streams
+	playing *sync.Mutex
+}
+
+// StreamsOutput lists the available streams and the one playing, if any
+type StreamsOutput struct {
+	Playing *StreamOutput  `json:"playing"`
+	Streams []StreamOutput `json:"streams"`
+}
+
+// PlayRequest is the body of a request to play a stream, e.g. {"stream": "YES", "gameNumber": 2}
+type PlayRequest struct {
+	Stream     string `json:"stream"`
+	GameNumber int    `json:"gameNumber"`
+}
+
+type errorOutput struct {
+	Error   string         `json:"error"`
+	Streams []StreamOutput `json:"streams,omitempty"`
+}
+
+// NewServer creates the REST API server. The lock guards the schedule and
+// streams while they are refreshed.
+func NewServer(ui *UI, gs *GameStreams, sl *Streamlink, events *EventStream, lock *sync.RWMutex, httpStream bool) (s Server) {
+	s.ui = ui
+	s.gamestreams = gs
+	s.streamlink = sl
+	s.events = events
+	s.lock = lock
+	s.httpStream = httpStream
+	s.playing = &sync.Mutex{}
+	return
+}
+
+// Handler routes the API requests
+func (s *Server) Handler() http.Handler {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/games", s.handleGames)
+	mux.HandleFunc("/games/", s.handleGame)
+	mux.HandleFunc("/streams", s.handleStreams)
+	mux.HandleFunc("/events", s.handleEvents)
+	mux.HandleFunc("/play", s.handlePlay)
+	mux.HandleFunc("/stop", s.handleStop)
+	mux.Handle("/", dashboardHandler())
+
+	return mux
+}
+
+// ListenAndServe serves the API on the address until it fails
+func (s *Server) ListenAndServe(address string) error {
+
+	log.WithFields(log.Fields{
+		"address": address,
+	}).Debug("Serving API")
+
+	return http.ListenAndServe(address, s.Handler())
+}
+
+// GET /games lists the games on the scoreboard
+func (s *Server) handleGames(w http.ResponseWriter, r *http.Request) {
+
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	writeJSON(w, http.StatusOK, s.ui.GetScheduleOutput())
+}
+
+// GET /games/{gamePk} shows a game
+func (s *Server) handleGame(w http.ResponseWriter, r *http.Request) {
+
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	pk, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/games/"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorOutput{Error: "game not found"})
+		return
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	o, ok := s.ui.GetGameOutput(pk)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorOutput{Error: "game not found"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, o)
+}
+
+// GET /streams lists the available streams and the one playing
+func (s *Server) handleStreams(w http.ResponseWriter, r *http.Request) {
+
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	o := StreamsOutput{Streams: []StreamOutput{}}
+
+	for _, g := range s.ui.GetScheduleOutput().Games {
+		o.Streams = append(o.Streams, g.Streams...)
+	}
+
+	if str, ok := s.streamlink.Playing(); ok {
+		playing := getStreamOutput(str)
+		o.Playing = &playing
+	}
+
+	writeJSON(w, http.StatusOK, o)
+}
+
+// GET /events pushes the changes found on each refresh as server-sent events
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorOutput{Error: "streaming is not supported"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ch := s.events.Subscribe()
+	defer s.events.Unsubscribe(ch)
+
+	ticker := time.NewTicker(keepAliveRate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-ch:
+			data, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		flusher.Flush()
+	}
+}
+
+// POST /play starts a stream by call letters or team unless one is playing
+func (s *Server) handlePlay(w http.ResponseWriter, r *http.Request) {
+
+	if !allowMethod(w, r, http.MethodPost) || !requireJSON(w, r) {
+		return
+	}
+
+	if !s.ui.config.CheckStreams {
+		writeJSON(w, http.StatusServiceUnavailable, errorOutput{Error: "streams are not checked"})
+		return
+	}
+
+	var req PlayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Stream == "" {
+		writeJSON(w, http.StatusBadRequest, errorOutput{Error: "set stream to call letters or a team"})
+		return
+	}
+
+	s.playing.Lock()
+	defer s.playing.Unlock()
+
+	if _, ok := s.streamlink.Playing(); ok {
+		writeJSON(w, http.StatusConflict, errorOutput{Error: "a stream is already playing, stop it first"})
+		return
+	}
+
+	s.lock.RLock()
+	strs := s.gamestreams.FindStreams(strings.ToUpper(req.Stream), req.GameNumber)
+	s.lock.RUnlock()
+
+	switch len(strs) {
+	case 0:
+		writeJSON(w, http.StatusNotFound, errorOutput{Error: "stream not found"})
+	case 1:
+		if err := s.streamlink.Start(strs[0], s.httpStream); err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorOutput{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, getStreamOutput(strs[0]))
+	default:
+		o := errorOutput{Error: "more than one stream matches, set gameNumber"}
+		for _, str := range strs {
+			o.Streams = append(o.Streams, getStreamOutput(str))
+		}
+		writeJSON(w, http.StatusConflict, o)
+	}
+}
+
+// POST /stop stops the stream playing
+func (s *Server) handleStop(w http.ResponseWriter, r *http.Request) {
+
+	if !allowMethod(w, r, http.MethodPost) || !requireJSON(w, r) {
+		return
+	}
+
+	s.playing.Lock()
+	defer s.playing.Unlock()
+
+	if err := s.streamlink.Stop(); err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorOutput{Error: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeJSON(w, http.StatusMethodNotAllowed, errorOutput{Error: "use " + method})
+		return false
+	}
+
+	return true
+}
+
+// requireJSON rejects requests other than JSON, so a web page can't play or
+// stop streams with a simple cross-origin POST that skips the CORS preflight
+func requireJSON(w http.ResponseWriter, r *http.Request) bool {
+
+	if t, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || t != "application/json" {
+		writeJSON(w, http.StatusUnsupportedMediaType, errorOutput{Error: "set Content-Type to application/json"})
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithFields(log.Fields{
+			"err": err,
+		}).Debug("Unable to write response")
+	}
+}
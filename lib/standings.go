@@ -0,0 +1,103 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TeamRecord contains a team's standing
+type TeamRecord struct {
+	Team              Team   `json:"team"`
+	Wins              int    `json:"wins"`
+	Losses            int    `json:"losses"`
+	WinningPercentage string `json:"winningPercentage"`
+	GamesBack         string `json:"gamesBack"`
+	WildCardGamesBack string `json:"wildCardGamesBack"`
+	DivisionRank      string `json:"divisionRank"`
+	WildCardRank      string `json:"wildCardRank"`
+	RunDifferential   int    `json:"runDifferential"`
+	Streak            struct {
+		StreakCode string `json:"streakCode"`
+	} `json:"streak"`
+	Records struct {
+		SplitRecords []struct {
+			Wins   int    `json:"wins"`
+			Losses int    `json:"losses"`
+			Type   string `json:"type"`
+		} `json:"splitRecords"`
+	} `json:"records"`
+}
+
+// LastTen returns the record of the team's last ten games
+func (tr *TeamRecord) LastTen() string {
+	for _, r := range tr.Records.SplitRecords {
+		if r.Type == "lastTen" {
+			return strconv.Itoa(r.Wins) + "-" + strconv.Itoa(r.Losses)
+		}
+	}
+	return ""
+}
+
+// StandingsRecord contains the standings of a division or a league's wild card race
+type StandingsRecord struct {
+	StandingsType string `json:"standingsType"`
+	League        struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"league"`
+	Division struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"division"`
+	TeamRecords []TeamRecord `json:"teamRecords"`
+}
+
+// Standings contains division and wild card standings
+type Standings struct {
+	Season    int
+	Divisions []StandingsRecord
+	WildCard  []StandingsRecord
+}
+
+func getStandingsRecords(url string, season int, standingsType string) (records []StandingsRecord, err error) {
+
+	resp, err := httpGet(fmt.Sprintf(url, season, standingsType))
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+
+	d := struct {
+		Records []StandingsRecord `json:"records"`
+	}{}
+
+	if err = json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return
+	}
+
+	records = d.Records
+
+	return
+}
+
+// GetStandings gets the division and wild card standings of a season
+func GetStandings(url string, season int) (st Standings, err error) {
+
+	log.WithFields(log.Fields{
+		"season": season,
+	}).Debug("Getting standings")
+
+	st.Season = season
+
+	if st.Divisions, err = getStandingsRecords(url, season, "regularSeason"); err != nil {
+		return
+	}
+
+	st.WildCard, err = getStandingsRecords(url, season, "wildCard")
+
+	return
+}
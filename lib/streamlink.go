@@ -4,24 +4,32 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"syscall"
 
 	log "github.com/sirupsen/logrus"
 )
 
-// Streamlink struct contains execution information streamlink
+// Streamlink struct contains execution information streamlink. The mutex
+// guards the process and the stream playing.
 type Streamlink struct {
 	path    string
+	mu      sync.Mutex
 	cmd     *exec.Cmd
 	Running bool
+	Stream  *Stream
+	Output  io.Writer
 }
 
 // NewStreamlink creates initialize the Streamlink struct
 func NewStreamlink() (s Streamlink, err error) {
 
+	s.Output = os.Stdout
+
 	streamlinkPaths := []string{"streamlink", "/usr/local/bin/streamlink"}
 	for _, path := range streamlinkPaths {
 		if s.path, err = exec.LookPath(path); err == nil {
@@ -41,37 +49,80 @@ func NewStreamlink() (s Streamlink, err error) {
 	return
 }
 
-// Run streamlink
+// Run streamlink until the stream ends
 func (s *Streamlink) Run(stream *Stream, http bool) (err error) {
 
+	cmd, stdout, err := s.start(stream)
+	if err != nil {
+		return
+	}
+
+	return s.wait(cmd, stdout)
+}
+
+// Start streamlink without waiting for the stream to end
+func (s *Streamlink) Start(stream *Stream, http bool) (err error) {
+
+	cmd, stdout, err := s.start(stream)
+	if err != nil {
+		return
+	}
+
+	go s.wait(cmd, stdout)
+
+	return
+}
+
+// Playing returns the stream playing, if any
+func (s *Streamlink) Playing() (*Stream, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Stream, s.Running && s.Stream != nil
+}
+
+func (s *Streamlink) start(stream *Stream) (cmd *exec.Cmd, stdout io.Reader, err error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.Running {
 		err = errors.New("stream is currently running")
 		return
 	}
 
-	s.cmd = exec.Command(s.path, fmt.Sprintf("hls://%s name_key=bitrate verify=False", stream.StreamPlaylist),
+	cmd = exec.Command(s.path, fmt.Sprintf("hls://%s name_key=bitrate verify=False", stream.StreamPlaylist),
 		"best", "--http-header", fmt.Sprintf("User-Agent=%s", UserAgent), "--hls-segment-threads=4",
 		"--https-proxy", "https://127.0.0.1:9876",
 		"--player-external-http",
 		"--player-external-http-port", "6789",
 	)
 
-	s.cmd.Env = os.Environ()
+	cmd.Env = os.Environ()
 
-	stdout, err := s.cmd.StdoutPipe()
-	if err != nil {
+	if stdout, err = cmd.StdoutPipe(); err != nil {
 		return
 	}
-	if err = s.cmd.Start(); err != nil {
+	if err = cmd.Start(); err != nil {
 		err = errors.New("unable to start streamlink")
 		return
 	}
 
 	log.WithFields(log.Fields{
-		"cmd": strings.Join(s.cmd.Args, " "),
+		"cmd": strings.Join(cmd.Args, " "),
 	}).Debug("Started streamlink")
 
+	s.cmd = cmd
 	s.Running = true
+	s.Stream = stream
+
+	return
+}
+
+// wait reads the output of cmd until the stream ends
+func (s *Streamlink) wait(cmd *exec.Cmd, stdout io.Reader) (err error) {
+
+	// a stream started after this one was stopped isn't stopped here
+	defer s.stop(cmd)
 
 	scanner := bufio.NewScanner(stdout)
 	scanner.Split(bufio.ScanLines)
@@ -81,11 +132,9 @@ func (s *Streamlink) Run(stream *Stream, http bool) (err error) {
 		// if 403 assume stream isn't available.
 		if match("403 Client Error: Forbidden", m) {
 			err = errors.New("Stream is not available")
-			s.Stop()
 			return
 		} else if match("Stream ended", m) {
-			fmt.Println("\nStream ended")
-			s.Stop()
+			fmt.Fprintln(s.Output, "\nStream ended")
 			return
 		}
 	}
@@ -94,8 +143,17 @@ func (s *Streamlink) Run(stream *Stream, http bool) (err error) {
 
 // Stop the streamlink process
 func (s *Streamlink) Stop() (err error) {
-	if s.Running {
-		err = s.cmd.Process.Signal(syscall.SIGTERM)
+	s.mu.Lock()
+	cmd := s.cmd
+	s.mu.Unlock()
+	return s.stop(cmd)
+}
+
+func (s *Streamlink) stop(cmd *exec.Cmd) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Running && s.cmd == cmd {
+		err = cmd.Process.Signal(syscall.SIGTERM)
 		s.Running = false
 		log.Debug("Stopped streamlink")
 	}
@@ -0,0 +1,52 @@
+package lib
+
+import (
+	"encoding/json"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetTeams gets the list of teams at the level
+func GetTeams(url string, level Level) (teams []Team, err error) {
+
+	log.Debug("Getting teams")
+
+	if level.SportID > 0 {
+		if url, err = setQuery(url, map[string]string{"sportId": strconv.Itoa(level.SportID)}); err != nil {
+			return
+		}
+	}
+
+	resp, err := httpGet(url)
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+
+	d := struct {
+		Teams []Team `json:"teams"`
+	}{}
+
+	if err = json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return
+	}
+
+	teams = d.Teams
+	for i := range teams {
+		teams[i].normalize()
+	}
+
+	return
+}
+
+// FindTeam finds a team by abbreviation, code or name
+func FindTeam(teams []Team, key string) (t Team, ok bool) {
+	for _, t = range teams {
+		if t.Matches(key) {
+			return t, true
+		}
+	}
+	return Team{}, false
+}
@@ -0,0 +1,31 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package lib
+
+import (
+	"errors"
+	"os"
+)
+
+type termState struct{}
+
+// IsTerminal is always false where raw mode isn't supported, so the
+// full-screen interface is never used
+func IsTerminal(f *os.File) bool {
+	return false
+}
+
+func makeRaw(f *os.File) (*termState, error) {
+	return nil, errors.New("full-screen mode is not supported on this platform")
+}
+
+func restoreTerminal(f *os.File, state *termState) error {
+	return nil
+}
+
+func terminalSize(f *os.File) (width, height int, err error) {
+	return 80, 24, nil
+}
+
+func notifyResize(ch chan os.Signal) {}
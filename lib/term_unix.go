@@ -0,0 +1,66 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package lib
+
+import (
+	"os"
+	"os/signal"
+
+	"golang.org/x/sys/unix"
+)
+
+// termState is the terminal mode restored when leaving raw mode
+type termState struct {
+	termios unix.Termios
+}
+
+// IsTerminal is true when the file is connected to a terminal
+func IsTerminal(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), ioctlReadTermios)
+	return err == nil
+}
+
+// makeRaw puts the terminal in raw mode so keys are read as they are pressed.
+// Output processing is left on so newlines still return the cursor.
+func makeRaw(f *os.File) (state *termState, err error) {
+
+	t, err := unix.IoctlGetTermios(int(f.Fd()), ioctlReadTermios)
+	if err != nil {
+		return
+	}
+
+	state = &termState{termios: *t}
+
+	t.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	t.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	t.Cflag &^= unix.CSIZE | unix.PARENB
+	t.Cflag |= unix.CS8
+	t.Cc[unix.VMIN] = 1
+	t.Cc[unix.VTIME] = 0
+
+	err = unix.IoctlSetTermios(int(f.Fd()), ioctlWriteTermios, t)
+
+	return
+}
+
+// restoreTerminal returns the terminal to the mode it was in before makeRaw
+func restoreTerminal(f *os.File, state *termState) error {
+	return unix.IoctlSetTermios(int(f.Fd()), ioctlWriteTermios, &state.termios)
+}
+
+// terminalSize returns the width and height of the terminal
+func terminalSize(f *os.File) (width, height int, err error) {
+
+	ws, err := unix.IoctlGetWinsize(int(f.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return
+	}
+
+	return int(ws.Col), int(ws.Row), nil
+}
+
+// notifyResize sends on the channel when the terminal window changes size
+func notifyResize(ch chan os.Signal) {
+	signal.Notify(ch, unix.SIGWINCH)
+}
@@ -0,0 +1,188 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const sgrReset = "\x1b[0m"
+
+// Theme sets the styles of the scoreboard. A style is a space separated list
+// of colors and attributes, e.g. "bold green", or "none".
+type Theme struct {
+	Live     string `json:"live"`
+	Leading  string `json:"leading"`
+	Favorite string `json:"favorite"`
+	Delayed  string `json:"delayed"`
+	Final    string `json:"final"`
+}
+
+var defaultTheme = Theme{
+	Live:     "green",
+	Leading:  "bold",
+	Favorite: "yellow",
+	Delayed:  "red",
+	Final:    "dim",
+}
+
+// sgrCodes are the ANSI select graphic rendition codes of the style names
+var sgrCodes = map[string]int{
+	"bold": 1, "dim": 2, "italic": 3, "underline": 4, "reverse": 7,
+	"black": 30, "red": 31, "green": 32, "yellow": 33, "blue": 34, "magenta": 35, "cyan": 36, "white": 37,
+	"bright-black": 90, "bright-red": 91, "bright-green": 92, "bright-yellow": 93,
+	"bright-blue": 94, "bright-magenta": 95, "bright-cyan": 96, "bright-white": 97,
+}
+
+// themeStyle is a style of the theme and its default
+type themeStyle struct {
+	style *string
+	def   string
+}
+
+// styles returns the theme's styles with their defaults
+func (t *Theme) styles() []themeStyle {
+	return []themeStyle{
+		{&t.Live, defaultTheme.Live},
+		{&t.Leading, defaultTheme.Leading},
+		{&t.Favorite, defaultTheme.Favorite},
+		{&t.Delayed, defaultTheme.Delayed},
+		{&t.Final, defaultTheme.Final},
+	}
+}
+
+// setDefaults fills in the styles not set and checks the others
+func (t *Theme) setDefaults() (err error) {
+
+	for _, s := range t.styles() {
+		if *s.style == "" {
+			*s.style = s.def
+		}
+		if _, err = parseStyle(*s.style); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// sgr converts the theme's styles to ANSI SGR parameters
+func (t Theme) sgr() Theme {
+
+	for _, s := range t.styles() {
+		*s.style, _ = parseStyle(*s.style)
+	}
+
+	return t
+}
+
+// parseStyle converts a style to ANSI SGR parameters, e.g. "bold green" to 1;32
+func parseStyle(style string) (sgr string, err error) {
+
+	var codes []string
+
+	for _, name := range strings.Fields(strings.ToLower(style)) {
+		if name == "none" {
+			continue
+		}
+		c, ok := sgrCodes[name]
+		if !ok {
+			return "", fmt.Errorf("unknown style %s", name)
+		}
+		codes = append(codes, strconv.Itoa(c))
+	}
+
+	return strings.Join(codes, ";"), nil
+}
+
+// colorEnabled is true when writing to a terminal and NO_COLOR isn't set
+func colorEnabled() bool {
+	return os.Getenv("NO_COLOR") == "" && IsTerminal(os.Stdout)
+}
+
+// paint applies the styles to each line of s, so table borders between the
+// lines of a cell keep the default color
+func (ui *UI) paint(s string, styles ...string) string {
+
+	if !ui.color {
+		return s
+	}
+
+	var sgr []string
+	for _, st := range styles {
+		if st != "" {
+			sgr = append(sgr, st)
+		}
+	}
+
+	if len(sgr) == 0 {
+		return s
+	}
+
+	lines := strings.Split(s, nl)
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = "\x1b[" + strings.Join(sgr, ";") + "m" + l + sgrReset
+		}
+	}
+
+	return strings.Join(lines, nl)
+}
+
+// getStatusStyle picks the style of a game from its state
+func (ui *UI) getStatusStyle(g *Game) string {
+
+	ds := g.GameStatus.DetailedState
+
+	switch {
+	case isDelayedSuspended(ds):
+		return ui.theme.Delayed
+	case isCompleteGame(ds):
+		return ui.theme.Final
+	case isActiveGame(ds):
+		return ui.theme.Live
+	}
+
+	return ""
+}
+
+// getTeamStyles picks the styles of a team playing in a game
+func (ui *UI) getTeamStyles(g *Game, home bool) (styles []string) {
+
+	t, runs, other := &g.Teams.Away.Team, g.LineScore.Scoring.Away.Runs, g.LineScore.Scoring.Home.Runs
+	if home {
+		t, runs, other = &g.Teams.Home.Team, other, runs
+	}
+
+	if ui.isFavorite(t) {
+		styles = append(styles, ui.theme.Favorite)
+	}
+
+	if runs > other && hasGameStarted(g.GameStatus.DetailedState) && !ui.hideSpoilers(g) {
+		styles = append(styles, ui.theme.Leading)
+	}
+
+	return
+}
+
+// visibleLength is the number of characters shown, not counting escape sequences
+func visibleLength(s string) (n int) {
+
+	escape := false
+
+	for _, r := range s {
+		switch {
+		case r == '\x1b':
+			escape = true
+		case escape:
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				escape = false
+			}
+		default:
+			n++
+		}
+	}
+
+	return
+}
@@ -0,0 +1,694 @@
+package lib
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxMessages is the number of lines of command output kept for scrolling
+	maxMessages = 1000
+
+	// escape sequences
+	altScreenOn  = "\x1b[?1049h"
+	altScreenOff = "\x1b[?1049l"
+	cursorHide   = "\x1b[?25l"
+	cursorShow   = "\x1b[?25h"
+	cursorHome   = "\x1b[H"
+	clearLine    = "\x1b[K"
+	clearBelow   = "\x1b[J"
+	reverseOn    = "\x1b[7m"
+	reverseOff   = "\x1b[27m"
+
+	tuiHelp = "↑/↓ select  enter play  b box  f follow  x stop  s standings  </> day  : command  ? help  q quit"
+)
+
+// TUI is a full-screen interface that redraws the scoreboard in place. The
+// selected game is acted on with single keys, and any prompt command can be
+// entered after ":". Command output is shown in a scrolling message pane.
+type TUI struct {
+	ui         *UI
+	streamlink *Streamlink
+	follower   *Follower
+	lock       *sync.RWMutex
+	in, out    *os.File
+	state      *termState
+	redraw     chan bool
+	keys       chan string
+
+	mu       sync.Mutex
+	messages []string
+	scroll   int
+	pane     int
+	// pending are escape sequences written to the terminal with the next frame
+	pending []string
+	// running is the command being run, if any
+	running string
+
+	selected    int
+	command     string
+	commandMode bool
+}
+
+// listRow is a line of the game list, a date header when gamePk is 0
+type listRow struct {
+	text   string
+	gamePk int
+}
+
+// NewTUI creates the full-screen interface. The lock guards the schedule and
+// streams while they are refreshed.
+func NewTUI(ui *UI, streamlink *Streamlink, follower *Follower, lock *sync.RWMutex) (t *TUI) {
+	t = &TUI{
+		ui:         ui,
+		streamlink: streamlink,
+		follower:   follower,
+		lock:       lock,
+		in:         os.Stdin,
+		out:        os.Stdout,
+		redraw:     make(chan bool, 1),
+		keys:       make(chan string),
+	}
+	return
+}
+
+// Write adds output to the message pane. The pane keeps showing the latest
+// output unless it has been scrolled back.
+func (t *TUI) Write(p []byte) (n int, err error) {
+
+	t.mu.Lock()
+
+	atBottom := t.scroll >= len(t.messages)-t.pane
+
+	t.messages = append(t.messages, strings.Split(strings.TrimRight(string(p), nl), nl)...)
+
+	if over := len(t.messages) - maxMessages; over > 0 {
+		t.messages = t.messages[over:]
+		t.scroll -= over
+	}
+
+	if atBottom {
+		t.scroll = len(t.messages) - t.pane
+	}
+	if t.scroll < 0 {
+		t.scroll = 0
+	}
+
+	t.mu.Unlock()
+
+	t.Redraw()
+
+	return len(p), nil
+}
+
+// Terminal returns a writer for escape sequences, such as notifications, that
+// are written to the terminal between frames so they don't break one up
+func (t *TUI) Terminal() io.Writer {
+	return terminalWriter{t}
+}
+
+type terminalWriter struct {
+	t *TUI
+}
+
+func (w terminalWriter) Write(p []byte) (n int, err error) {
+
+	w.t.mu.Lock()
+	w.t.pending = append(w.t.pending, string(p))
+	w.t.mu.Unlock()
+
+	w.t.Redraw()
+
+	return len(p), nil
+}
+
+// Redraw updates the screen with the current schedule and state
+func (t *TUI) Redraw() {
+	select {
+	case t.redraw <- true:
+	default:
+	}
+}
+
+// Run takes over the terminal until the program exits. Each key or command is
+// turned into a prompt command and passed to the handler.
+func (t *TUI) Run(handler func(input string)) (err error) {
+
+	if t.state, err = makeRaw(t.in); err != nil {
+		return
+	}
+
+	fmt.Fprint(t.out, altScreenOn+cursorHide)
+
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+
+	go t.readKeys()
+
+	for {
+		t.draw()
+
+		select {
+		case k := <-t.keys:
+			// the handler takes the lock itself
+			t.lock.RLock()
+			input, ok := t.handleKey(k)
+			t.lock.RUnlock()
+			if ok {
+				t.run(handler, input)
+			}
+		case <-t.redraw:
+		case <-resize:
+		}
+	}
+}
+
+// Close restores the terminal
+func (t *TUI) Close() {
+	if t.state != nil {
+		fmt.Fprint(t.out, cursorShow+altScreenOff)
+		restoreTerminal(t.in, t.state)
+		t.state = nil
+	}
+}
+
+// run passes a command to the handler and scrolls the message pane to the
+// start of its output. Commands run in the background so the screen keeps
+// updating while they make requests, one at a time. Quitting doesn't wait.
+func (t *TUI) run(handler func(input string), input string) {
+
+	if input == "Q" {
+		handler(input)
+		return
+	}
+
+	t.mu.Lock()
+	if t.running != "" {
+		t.mu.Unlock()
+		return
+	}
+	t.running = input
+	start := len(t.messages)
+	t.mu.Unlock()
+
+	go func() {
+		handler(input)
+
+		t.mu.Lock()
+		if len(t.messages) > start {
+			t.scroll = start
+		}
+		t.running = ""
+		t.mu.Unlock()
+
+		t.Redraw()
+	}()
+}
+
+// readKeys sends each key pressed, named when it isn't a printable character
+func (t *TUI) readKeys() {
+
+	names := map[string]string{
+		"\x1b[A": "up", "\x1bOA": "up",
+		"\x1b[B": "down", "\x1bOB": "down",
+		"\x1b[5~": "pgup", "\x1b[6~": "pgdn",
+		"\r": "enter", "\n": "enter",
+		"\x7f": "backspace", "\b": "backspace",
+		"\x1b": "esc", "\x03": "ctrl-c",
+	}
+
+	buf := make([]byte, 64)
+
+	for {
+		n, err := t.in.Read(buf)
+		if err != nil {
+			return
+		}
+
+		// several keys arrive together when typing fast or pasting
+		for in := string(buf[:n]); in != ""; {
+
+			k := in[:1]
+			if strings.HasPrefix(in, "\x1b") && len(in) > 1 {
+				k = in[:escapeLength(in)]
+			} else if _, size := utf8.DecodeRuneInString(in); size > 1 {
+				k = in[:size]
+			}
+			in = in[len(k):]
+
+			if name, ok := names[k]; ok {
+				k = name
+			} else if strings.HasPrefix(k, "\x1b") {
+				// ignore keys not used
+				continue
+			}
+
+			t.keys <- k
+		}
+	}
+}
+
+// escapeLength is the length of the escape sequence at the start of the input
+func escapeLength(in string) int {
+
+	if in[1] != '[' && in[1] != 'O' {
+		return 1
+	}
+
+	for i := 2; i < len(in); i++ {
+		if c := in[i]; c >= 0x40 && c <= 0x7e {
+			return i + 1
+		}
+	}
+
+	return len(in)
+}
+
+// handleKey updates the screen state for a key, returning the prompt command it runs if any
+func (t *TUI) handleKey(k string) (input string, ok bool) {
+
+	if t.commandMode {
+		switch k {
+		case "enter":
+			t.commandMode = false
+			return strings.ToUpper(strings.TrimSpace(t.command)), true
+		case "esc", "ctrl-c":
+			t.commandMode = false
+		case "backspace":
+			if _, size := utf8.DecodeLastRuneInString(t.command); size > 0 {
+				t.command = t.command[:len(t.command)-size]
+			}
+		default:
+			if k != "up" && k != "down" && k != "pgup" && k != "pgdn" {
+				t.command += k
+			}
+		}
+		return
+	}
+
+	g, selected := t.selectedGame()
+
+	// keys that act on the selected game
+	gameCommands := map[string]string{"b": "B ", "l": "LINE ", "f": "FOLLOW ", "v": "REVEAL "}
+
+	switch k {
+	case "up", "k":
+		t.moveSelection(-1)
+	case "down", "j":
+		t.moveSelection(1)
+	case "pgup":
+		t.scrollMessages(-1)
+	case "pgdn":
+		t.scrollMessages(1)
+	case ":":
+		t.commandMode = true
+		t.command = ""
+	case "enter":
+		if selected {
+			return t.streamKey(&g), true
+		}
+	case "b", "l", "f", "v":
+		if selected {
+			return gameCommands[k] + strconv.Itoa(g.GamePk), true
+		}
+	case "u":
+		return "UNFOLLOW", true
+	case "x":
+		return "STOP", true
+	case "p", "s", "r", "<", ">":
+		return strings.ToUpper(k), true
+	case "?", "h":
+		return "H", true
+	case "q", "ctrl-c":
+		return "Q", true
+	}
+
+	return
+}
+
+// streamKey picks the feed of a favorite team playing in the game, otherwise the home team's
+func (t *TUI) streamKey(g *Game) (key string) {
+
+	key = g.Teams.Home.Team.Abbreviation
+	if t.ui.isFavorite(&g.Teams.Away.Team) && !t.ui.isFavorite(&g.Teams.Home.Team) {
+		key = g.Teams.Away.Team.Abbreviation
+	}
+
+	if g.IsDoubleHeader() {
+		key += " " + strconv.Itoa(g.GameNumber)
+	}
+
+	return
+}
+
+// rows lists the games shown on the scoreboard, with a header for each day
+func (t *TUI) rows() (rows []listRow) {
+
+	multiDay := len(t.ui.schedule.Dates) > 1
+
+	for i := range t.ui.schedule.Dates {
+		d := &t.ui.schedule.Dates[i]
+
+		if multiDay {
+			rows = append(rows, listRow{text: d.Date})
+		}
+
+		total := 0
+		for _, g := range t.ui.pinFavorites(d.Games) {
+			if !t.ui.showGame(&g) {
+				continue
+			}
+			rows = append(rows, listRow{text: t.getGameRowDisplay(&g), gamePk: g.GamePk})
+			total++
+		}
+
+		if total == 0 {
+			rows = append(rows, listRow{text: "  No Games"})
+		}
+	}
+
+	return
+}
+
+// getGameRowDisplay shows a game on a single line, e.g. NYY  5  BOS  3  Bot 7th
+func (t *TUI) getGameRowDisplay(g *Game) string {
+
+	ui := t.ui
+	away, home := "", ""
+
+	if hasGameStarted(g.GameStatus.DetailedState) && !ui.hideSpoilers(g) {
+		away = strconv.Itoa(g.LineScore.Scoring.Away.Runs)
+		home = strconv.Itoa(g.LineScore.Scoring.Home.Runs)
+	}
+
+	status := ui.getInningDisplay(g)
+	if sc := g.GameStatus.StatusCode; sc == "S" || sc == "P" || sc == "PW" {
+		gt, _ := time.Parse(time.RFC3339, g.GameDate)
+		status = timeFormat(&gt, false)
+	}
+
+	var extra []string
+	if g.IsDoubleHeader() {
+		extra = append(extra, "Gm "+strconv.Itoa(g.GameNumber))
+	}
+	if ui.isFavorite(&g.Teams.Away.Team) || ui.isFavorite(&g.Teams.Home.Team) {
+		extra = append(extra, "*")
+	}
+	for _, s := range ui.streams[g.GamePk] {
+		extra = append(extra, s.CallLetters)
+	}
+
+	team := func(t *Team, runs string, home bool) string {
+		return ui.paint(padRight(t.Abbreviation, 5)+" "+fmt.Sprintf("%2s", runs), ui.getTeamStyles(g, home)...)
+	}
+
+	return team(&g.Teams.Away.Team, away, false) + "  " + team(&g.Teams.Home.Team, home, true) + "  " +
+		padRight(ui.paint(status, ui.getStatusStyle(g)), 14) + " " + strings.Join(extra, " ")
+}
+
+// selectedGame returns the selected game, selecting the first game shown when
+// the previous selection is no longer on the scoreboard
+func (t *TUI) selectedGame() (g Game, ok bool) {
+
+	first := 0
+	for _, r := range t.rows() {
+		if r.gamePk == 0 {
+			continue
+		}
+		if r.gamePk == t.selected {
+			return t.ui.schedule.GameMap[r.gamePk], true
+		}
+		if first == 0 {
+			first = r.gamePk
+		}
+	}
+
+	t.selected = first
+	g, ok = t.ui.schedule.GameMap[first]
+
+	return
+}
+
+// moveSelection selects the game before or after the selected one
+func (t *TUI) moveSelection(direction int) {
+
+	var pks []int
+	for _, r := range t.rows() {
+		if r.gamePk != 0 {
+			pks = append(pks, r.gamePk)
+		}
+	}
+
+	for i, pk := range pks {
+		if pk == t.selected {
+			if j := i + direction; j >= 0 && j < len(pks) {
+				t.selected = pks[j]
+			}
+			return
+		}
+	}
+}
+
+// scrollMessages moves the message pane a page up or down
+func (t *TUI) scrollMessages(direction int) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.scroll += direction * t.pane
+	if max := len(t.messages) - t.pane; t.scroll > max {
+		t.scroll = max
+	}
+	if t.scroll < 0 {
+		t.scroll = 0
+	}
+}
+
+// layout splits the screen height between the game list, the selected game and the messages
+func (t *TUI) layout(rows, details int) (listHeight, detailHeight, messageHeight int) {
+
+	_, h, err := terminalSize(t.out)
+	if err != nil || h == 0 {
+		h = 24
+	}
+
+	// header, blank line, message divider, status bar and command line
+	free := h - 5
+	if free < 0 {
+		free = 0
+	}
+
+	listHeight = rows
+	if listHeight > free/2 {
+		listHeight = free / 2
+	}
+
+	detailHeight = details
+	if detailHeight > free-listHeight {
+		detailHeight = free - listHeight
+	}
+
+	messageHeight = free - listHeight - detailHeight
+
+	return
+}
+
+// getDetailLines shows the selected game's teams and streams beside its status
+func (t *TUI) getDetailLines(g *Game) (lines []string) {
+
+	if g.GamePk == 0 {
+		return
+	}
+
+	left := strings.Split(t.ui.getTeamDisplay(g, false)+t.ui.getSeriesDisplay(g), nl)
+	if s := strings.TrimSpace(t.ui.getStreamDisplay(g, false)); s != "" {
+		left = append(left, strings.Split(s, nl)...)
+	}
+
+	right := strings.Split(t.ui.getGameStatusDisplay(g, false), nl)
+
+	width := 0
+	for _, l := range left {
+		if n := visibleLength(l); n > width {
+			width = n
+		}
+	}
+
+	for i := 0; i < len(left) || i < len(right); i++ {
+		l, r := "", ""
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		lines = append(lines, "  "+l+strings.Repeat(" ", width-visibleLength(l))+"   "+r)
+	}
+
+	return
+}
+
+// getStatusDisplay shows the last refresh, the running stream and the followed game
+func (t *TUI) getStatusDisplay() string {
+
+	status := []string{"Refreshed " + timeFormat(&t.ui.schedule.LastRefreshed, false)}
+
+	t.mu.Lock()
+	if t.running != "" {
+		status = append([]string{"Running " + strings.ToLower(t.running) + "..."}, status...)
+	}
+	t.mu.Unlock()
+
+	if t.streamlink != nil {
+		if s, ok := t.streamlink.Playing(); ok {
+			g := t.ui.schedule.GameMap[s.GamePk]
+			status = append(status, "Playing "+s.CallLetters+" ("+g.Teams.Away.Team.Abbreviation+" vs "+g.Teams.Home.Team.Abbreviation+")")
+		}
+	}
+
+	if t.follower != nil {
+		if g, ok := t.follower.Following(); ok {
+			status = append(status, "Following "+g.Teams.Away.Team.Abbreviation+" vs "+g.Teams.Home.Team.Abbreviation)
+		}
+	}
+
+	if !t.ui.filter.IsEmpty() {
+		status = append(status, "Filter: "+t.ui.filter.String())
+	}
+
+	return strings.Join(status, " | ")
+}
+
+func (t *TUI) draw() {
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	w, _, err := terminalSize(t.out)
+	if err != nil || w == 0 {
+		w = 80
+	}
+
+	rows := t.rows()
+	g, _ := t.selectedGame()
+	details := t.getDetailLines(&g)
+	listHeight, detailHeight, messageHeight := t.layout(len(rows), len(details))
+
+	s := t.ui.schedule
+	title := "Scoreboard for " + s.Date
+	if s.EndDate != s.Date {
+		title += " to " + s.EndDate
+	}
+
+	lines := []string{title}
+
+	// keep the selected game in view
+	top, selectedRow := 0, 0
+	for i, r := range rows {
+		if r.gamePk != 0 && r.gamePk == t.selected {
+			selectedRow = i
+		}
+	}
+	if selectedRow >= listHeight {
+		top = selectedRow - listHeight + 1
+	}
+
+	for i := top; i < top+listHeight && i < len(rows); i++ {
+		r := rows[i]
+		switch {
+		case r.gamePk == 0:
+			lines = append(lines, r.text)
+		case r.gamePk == t.selected:
+			// keep the selection highlighted after each colored part
+			lines = append(lines, reverseOn+"> "+strings.ReplaceAll(r.text, sgrReset, sgrReset+reverseOn)+reverseOff)
+		default:
+			lines = append(lines, "  "+r.text)
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, details[:detailHeight]...)
+	lines = append(lines, strings.Repeat("─", w))
+
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = nil
+	t.pane = messageHeight
+	for i := t.scroll; i < t.scroll+messageHeight; i++ {
+		if i < len(t.messages) {
+			lines = append(lines, t.messages[i])
+		} else {
+			lines = append(lines, "")
+		}
+	}
+	t.mu.Unlock()
+
+	lines = append(lines, reverseOn+padRight(t.getStatusDisplay(), w)+reverseOff)
+
+	if t.commandMode {
+		lines = append(lines, ":"+t.command)
+	} else {
+		lines = append(lines, tuiHelp)
+	}
+
+	sb := &strings.Builder{}
+	sb.WriteString(cursorHome)
+	for i, l := range lines {
+		if i > 0 {
+			sb.WriteString(nl)
+		}
+		sb.WriteString(truncate(l, w) + clearLine)
+	}
+	sb.WriteString(clearBelow)
+
+	if t.commandMode {
+		sb.WriteString(cursorShow)
+	} else {
+		sb.WriteString(cursorHide)
+	}
+
+	for _, p := range pending {
+		sb.WriteString(p)
+	}
+
+	fmt.Fprint(t.out, sb.String())
+}
+
+// truncate cuts a line to the width of the screen, not counting escape sequences
+func truncate(s string, width int) string {
+
+	sb := &strings.Builder{}
+	n := 0
+	escape := false
+
+	for _, r := range s {
+		switch {
+		case r == '\x1b':
+			escape = true
+		case escape:
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				escape = false
+			}
+		case n >= width:
+			continue
+		default:
+			n++
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+func padRight(s string, width int) string {
+	if n := visibleLength(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,8 @@ import (
 
 const (
 	nl = "\n"
+
+	spoilerNotice = "Hidden to avoid spoilers. Use reveal [team|gamePk] to show.\n"
 )
 
 // UI struct
@@ -20,27 +23,87 @@ type UI struct {
 	config   *Config
 	schedule *Schedule
 	streams  map[int]map[string]*Stream
-	team     string
+	filter   *Filter
+	revealed map[int]bool
+	color    bool
+	theme    Theme
 }
 
 // NewUI creates the UI struct
-func NewUI(c *Config, s *Schedule, streams map[int]map[string]*Stream, team string) (ui UI) {
+func NewUI(c *Config, s *Schedule, streams map[int]map[string]*Stream, filter *Filter) (ui UI) {
 	ui.config = c
 	ui.schedule = s
 	ui.streams = streams
-	ui.team = team
+	ui.filter = filter
+	ui.revealed = make(map[int]bool)
+	ui.color = colorEnabled()
+	ui.theme = c.Theme.sgr()
 	return
 }
 
-// GenerateScoreboard builds the scoreboard display
+// Reveal shows the score and state of a game hidden to avoid spoilers
+func (ui *UI) Reveal(g *Game) {
+	ui.revealed[g.GamePk] = true
+}
+
+// hideSpoilers is true when the score and state of a game shouldn't be shown
+func (ui *UI) hideSpoilers(g *Game) bool {
+	return hasGameStarted(g.GameStatus.DetailedState) && ui.hideResults(g)
+}
+
+// hideResults is true when results involving the game's teams, such as the
+// series standing, shouldn't be shown, whether or not the game has started
+func (ui *UI) hideResults(g *Game) bool {
+
+	if ui.revealed[g.GamePk] {
+		return false
+	}
+
+	if ui.config.NoSpoilers {
+		return true
+	}
+
+	for _, t := range ui.config.SpoilerTeams {
+		if g.Teams.Away.Team.Matches(t) || g.Teams.Home.Team.Matches(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getSpoilerFreeStatus only shows whether a game is in progress or over
+func (ui *UI) getSpoilerFreeStatus(g *Game) string {
+	if isCompleteGame(g.GameStatus.DetailedState) {
+		return "Final"
+	}
+	return "In Progress"
+}
+
+// GenerateScoreboard builds the scoreboard display with a table for each day
 func (ui *UI) GenerateScoreboard() string {
 
 	ts := &strings.Builder{}
+	total := 0
+
+	for i := range ui.schedule.Dates {
+		total += ui.generateDayScoreboard(ts, &ui.schedule.Dates[i])
+	}
+
+	if total > 0 && ui.config.CheckStreams && len(ui.streams) == 0 {
+		ts.WriteString("No streams available.\n------\n")
+	}
+
+	return ts.String()
+
+}
+
+func (ui *UI) generateDayScoreboard(ts *strings.Builder, d *ScheduleDate) (total int) {
+
 	table := tablewriter.NewWriter(ts)
 	table.SetRowLine(true)
+	table.SetAutoWrapText(false)
 
-	var v []string
-	var total = 0
 	showScore := false
 	showStreams := ui.showStreams()
 
@@ -48,67 +111,210 @@ func (ui *UI) GenerateScoreboard() string {
 		showScore = true
 	}
 
-	fmt.Println("------\nScoreboard for", ui.schedule.Date, "(as of "+timeFormat(&ui.schedule.LastRefreshed, false)+")")
+	ts.WriteString("------\nScoreboard for " + d.Date + " (as of " + timeFormat(&ui.schedule.LastRefreshed, false) + ")\n")
 
-	for i, g := range *ui.schedule.Games {
+	if !ui.filter.IsEmpty() {
+		ts.WriteString("Filter: " + ui.filter.String() + "\n")
+	}
 
-		col := i % 2
+	var shown []*Game
+	for _, g := range ui.pinFavorites(d.Games) {
+		if ui.showGame(&g) {
+			g := g
+			shown = append(shown, &g)
+		}
+	}
 
-		if ui.team != "" && (g.Teams.Away.Team.Abbreviation != ui.team && g.Teams.Home.Team.Abbreviation != ui.team) {
-			continue
+	total = len(shown)
+
+	if total == 0 {
+		ts.WriteString("No Games\n")
+		return
+	}
+
+	cells := func(compact bool) (c [][]string) {
+		for _, g := range shown {
+			c = append(c, ui.getGameCells(g, showScore, showStreams, compact))
 		}
+		return
+	}
 
-		v = append(v, ui.getTeamDisplay(&g, false))
+	compact := ui.config.Compact
+	games := cells(compact)
 
-		if showScore {
-			v = append(v, ui.getGameScoreDisplay(&g))
+	// two games per row unless the width of the terminal is known
+	columns := 2
+	if width := terminalWidth(); width > 0 {
+		columns = scoreboardColumns(games, width)
+		if columns == 0 && !compact {
+			games = cells(true)
+			columns = scoreboardColumns(games, width)
+		}
+		if columns == 0 {
+			columns = 1
 		}
+	}
+
+	if columns > total {
+		columns = total
+	}
+
+	for i := 0; i < total; i += columns {
+
+		var row []string
+
+		for col := 0; col < columns; col++ {
+			if col > 0 {
+				// separator
+				row = append(row, nl)
+			}
+			if i+col < total {
+				row = append(row, games[i+col]...)
+			} else {
+				// uneven game count
+				for range games[0] {
+					row = append(row, nl)
+				}
+			}
+		}
+
+		table.Append(evenLines(row))
+	}
+
+	table.Render()
+
+	return
+
+}
+
+// getGameCells builds the cells of a game on the scoreboard. Compact cells
+// only show team abbreviations and leave out pre-game details.
+func (ui *UI) getGameCells(g *Game, showScore, showStreams, compact bool) (cells []string) {
+
+	if compact {
+		cells = append(cells, ui.getCompactTeamDisplay(g))
+	} else {
+		cells = append(cells, ui.getTeamDisplay(g, false)+ui.getSeriesDisplay(g))
+	}
 
-		v = append(v, ui.getGameStatusDisplay(&g))
+	if showScore {
+		cells = append(cells, ui.getGameScoreDisplay(g))
+	}
+
+	cells = append(cells, ui.getGameStatusDisplay(g, compact))
+
+	if showStreams {
+		cells = append(cells, ui.getStreamDisplay(g, compact))
+	}
 
-		if showStreams {
-			v = append(v, ui.getStreamDisplay(&g))
+	return
+}
+
+// terminalWidth is the width of the terminal, or of $COLUMNS when the output
+// isn't a terminal. It's 0 when unknown.
+func terminalWidth() int {
+
+	if IsTerminal(os.Stdout) {
+		if w, _, err := terminalSize(os.Stdout); err == nil && w > 0 {
+			return w
 		}
+	}
 
-		if col == 0 {
-			if ui.team == "" {
-				v = append(v, nl)
+	w, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+
+	return w
+}
+
+// scoreboardColumns is the most games that fit side by side in the width.
+// Each cell takes the widest line in its column, padding and a border.
+func scoreboardColumns(games [][]string, width int) int {
+
+	for columns := len(games); columns > 0; columns-- {
+
+		widths := make([]int, columns*len(games[0]))
+
+		for g, cells := range games {
+			for i, c := range cells {
+				col := (g%columns)*len(cells) + i
+				for _, l := range strings.Split(c, nl) {
+					if n := visibleLength(l); n > widths[col] {
+						widths[col] = n
+					}
+				}
 			}
-		} else {
-			table.Append(v)
-			v = []string{}
 		}
 
-		total = total + 1
+		// left border, and an empty separator column between games
+		total := 1 + (columns-1)*3
+		for _, w := range widths {
+			total += w + 3
+		}
 
+		if total <= width {
+			return columns
+		}
 	}
 
-	if total == 0 {
-		ts.WriteString("No Games\n")
-		// uneven game count
-	} else if total == 1 && len(v) > 0 {
-		table.Append(v)
-	} else if len(v) > 0 {
-		pad := []string{nl, nl}
-		if showScore {
-			pad = append(pad, nl)
+	return 0
+}
+
+// evenLines pads the cells of a row to the same number of lines. The table
+// pads short cells with more spaces than a narrow column is wide.
+func evenLines(row []string) []string {
+
+	max := 0
+	for _, c := range row {
+		if n := strings.Count(c, nl); n > max {
+			max = n
 		}
-		if showStreams {
-			pad = append(pad, nl)
+	}
+
+	for i, c := range row {
+		row[i] = c + strings.Repeat(nl, max-strings.Count(c, nl))
+	}
+
+	return row
+}
+
+// favoriteRank returns the position of the game's first favorite team in the
+// favorites list, or the length of the list when neither team is a favorite
+func (ui *UI) favoriteRank(g *Game) int {
+	for i, f := range ui.config.Favorites {
+		if g.Teams.Away.Team.Matches(f) || g.Teams.Home.Team.Matches(f) {
+			return i
 		}
-		table.Append(append(v, pad...))
 	}
+	return len(ui.config.Favorites)
+}
 
-	if table.NumLines() > 0 {
-		table.Render()
+func (ui *UI) isFavorite(t *Team) bool {
+	for _, f := range ui.config.Favorites {
+		if t.Matches(f) {
+			return true
+		}
 	}
+	return false
+}
 
-	if total > 0 && ui.config.CheckStreams && len(ui.streams) == 0 {
-		ts.WriteString("No streams available.\n------\n")
+// pinFavorites orders games of favorite teams first, in the order of the favorites list
+func (ui *UI) pinFavorites(games []Game) []Game {
+
+	if len(ui.config.Favorites) == 0 {
+		return games
 	}
 
-	return ts.String()
+	pinned := make([]Game, len(games))
+	copy(pinned, games)
 
+	sort.SliceStable(pinned, func(i, j int) bool {
+		return ui.favoriteRank(&pinned[i]) < ui.favoriteRank(&pinned[j])
+	})
+
+	return pinned
+}
+
+func (ui *UI) showGame(g *Game) bool {
+	return ui.filter.Match(g, len(ui.streams[g.GamePk]) > 0)
 }
 
 func (ui *UI) showStreams() bool {
@@ -118,7 +324,7 @@ func (ui *UI) showStreams() bool {
 	return false
 }
 
-func (ui *UI) getStreamDisplay(g *Game) (s string) {
+func (ui *UI) getStreamDisplay(g *Game, compact bool) (s string) {
 
 	if len(ui.streams[g.GamePk]) == 0 {
 		return nl
@@ -127,7 +333,11 @@ func (ui *UI) getStreamDisplay(g *Game) (s string) {
 	var streamDisplay strings.Builder
 
 	for _, s := range ui.streams[g.GamePk] {
-		streamDisplay.WriteString(s.MediaFeedType + " [" + s.CallLetters + "]\n")
+		if compact {
+			streamDisplay.WriteString(s.CallLetters + "\n")
+		} else {
+			streamDisplay.WriteString(s.MediaFeedType + " [" + s.CallLetters + "]\n")
+		}
 	}
 
 	s = strings.TrimSpace(streamDisplay.String())
@@ -155,6 +365,29 @@ func (ui *UI) GenerateStreamTable(streams []*Stream) string {
 
 }
 
+// GenerateStreamList shows the streams of the games on the scoreboard
+func (ui *UI) GenerateStreamList() string {
+
+	ts := &strings.Builder{}
+	table := tablewriter.NewWriter(ts)
+	table.SetHeader([]string{"Call Letters", "Feed", "Game", "ID"})
+
+	for _, g := range ui.GetScheduleOutput().Games {
+		sg := ui.schedule.GameMap[g.GamePk]
+		for _, s := range g.Streams {
+			table.Append([]string{s.CallLetters, s.FeedType, ui.getTeamDisplay(&sg, true), s.ID})
+		}
+	}
+
+	if table.NumLines() == 0 {
+		return "No streams available.\n"
+	}
+
+	table.Render()
+
+	return ts.String()
+}
+
 // GetStartStreamlinkDisplay to show details of the selected stream
 func (ui *UI) GetStartStreamlinkDisplay(s *Stream) (d string) {
 	g := ui.schedule.GameMap[s.GamePk]
@@ -168,10 +401,84 @@ func (ui *UI) getTeamDisplay(g *Game, singleLine bool) string {
 	if singleLine {
 		delim = " vs "
 	}
-	return g.Teams.Away.Team.Name + " (" + g.Teams.Away.Team.Abbreviation + ")" + delim + g.Teams.Home.Team.Name + " (" + g.Teams.Home.Team.Abbreviation + ")"
+
+	team := func(t *Team, home bool) string {
+		d := t.Name + " (" + t.Abbreviation + ")"
+		if singleLine {
+			return d
+		}
+		if ui.isFavorite(t) {
+			d += " *"
+		}
+		return ui.paint(d, ui.getTeamStyles(g, home)...)
+	}
+
+	d := team(&g.Teams.Away.Team, false) + delim + team(&g.Teams.Home.Team, true)
+
+	if g.IsDoubleHeader() {
+		if singleLine {
+			d += " (Gm " + strconv.Itoa(g.GameNumber) + ")"
+		} else {
+			d += nl + "Gm " + strconv.Itoa(g.GameNumber)
+		}
+	}
+
+	return d
+}
+
+// getCompactTeamDisplay shows the abbreviations of the teams
+func (ui *UI) getCompactTeamDisplay(g *Game) string {
+
+	team := func(t *Team, home bool) string {
+		d := t.Abbreviation
+		if ui.isFavorite(t) {
+			d += " *"
+		}
+		return ui.paint(d, ui.getTeamStyles(g, home)...)
+	}
+
+	d := team(&g.Teams.Away.Team, false) + nl + team(&g.Teams.Home.Team, true)
+
+	if g.IsDoubleHeader() {
+		d += nl + "Gm " + strconv.Itoa(g.GameNumber)
+	}
+
+	return d
+}
+
+// getSeriesDisplay shows the game's place in its series, e.g. ALCS Game 3 of 7 — NYY leads 2-0
+func (ui *UI) getSeriesDisplay(g *Game) string {
+
+	if g.GamesInSeries == 0 {
+		return ""
+	}
+
+	sd := &strings.Builder{}
+	sd.WriteString(nl)
+
+	if isPostseason(g.GameType) {
+		if g.SeriesStatus != nil && g.SeriesStatus.ShortName != "" {
+			sd.WriteString(g.SeriesStatus.ShortName + " ")
+		} else if g.SeriesDescription != "" {
+			sd.WriteString(g.SeriesDescription + " ")
+		}
+	}
+
+	sd.WriteString("Game " + strconv.Itoa(g.SeriesGameNumber) + " of " + strconv.Itoa(g.GamesInSeries))
+
+	// the standing gives away earlier games of the series
+	if g.SeriesStatus != nil && g.SeriesStatus.Result != "" && !ui.hideResults(g) {
+		sd.WriteString(" — " + g.SeriesStatus.Result)
+	}
+
+	return sd.String()
 }
 
-func (ui *UI) getGameStatusDisplay(g *Game) string {
+func (ui *UI) getGameStatusDisplay(g *Game, compact bool) string {
+
+	if ui.hideSpoilers(g) {
+		return ui.paint(ui.getSpoilerFreeStatus(g), ui.getStatusStyle(g))
+	}
 
 	sc := g.GameStatus.StatusCode
 	sd := &strings.Builder{}
@@ -184,33 +491,461 @@ func (ui *UI) getGameStatusDisplay(g *Game) string {
 			// warmup
 			sd.WriteString(nl + g.GameStatus.DetailedState)
 		}
+		if !compact {
+			sd.WriteString(ui.getPreGameDisplay(g))
+		}
 	} else if isActiveGame(g.GameStatus.DetailedState) {
 
-		sd.WriteString(g.LineScore.InningState[0:3] + " " + g.LineScore.CurrentInningOrdinal)
+		sd.WriteString(ui.getInningDisplay(g))
 
 		if isDelayedSuspended(g.GameStatus.DetailedState) {
 			sd.WriteString(nl + g.GameStatus.DetailedState)
+		} else {
+			sd.WriteString(ui.getSituationDisplay(g))
 		}
 
 	} else {
 		sd.WriteString(g.GameStatus.DetailedState)
 	}
 
+	return ui.paint(sd.String(), ui.getStatusStyle(g))
+
+}
+
+func (ui *UI) getInningDisplay(g *Game) string {
+
+	if ui.hideSpoilers(g) {
+		return ui.getSpoilerFreeStatus(g)
+	}
+
+	if !isActiveGame(g.GameStatus.DetailedState) || len(g.LineScore.InningState) < 3 {
+		return g.GameStatus.DetailedState
+	}
+
+	return g.LineScore.InningState[0:3] + " " + g.LineScore.CurrentInningOrdinal
+}
+
+// getPreGameDisplay shows the probable starters, venue and TV broadcasts when available
+func (ui *UI) getPreGameDisplay(g *Game) string {
+
+	sd := &strings.Builder{}
+
+	away, home := g.Teams.Away.ProbablePitcher, g.Teams.Home.ProbablePitcher
+	if away != nil || home != nil {
+		for _, p := range []*Person{away, home} {
+			sd.WriteString(nl)
+			if p == nil || p.FullName == "" {
+				sd.WriteString("TBD")
+				continue
+			}
+			sd.WriteString(lastName(p.FullName))
+			if r, ok := p.PitchingRecord(); ok {
+				sd.WriteString(" (" + r + ")")
+			}
+		}
+	}
+
+	if g.Venue.Name != "" {
+		sd.WriteString(nl + g.Venue.Name)
+	}
+
+	var tv []string
+	seen := make(map[string]bool)
+	for _, b := range g.Broadcasts {
+		if b.Type == "TV" && !seen[b.Name] {
+			seen[b.Name] = true
+			tv = append(tv, b.Name)
+		}
+	}
+	if len(tv) > 0 {
+		sd.WriteString(nl + "TV: " + strings.Join(tv, ", "))
+	}
+
 	return sd.String()
+}
+
+// getSituationDisplay shows the count, outs, runners on base and the current matchup
+func (ui *UI) getSituationDisplay(g *Game) string {
+
+	ls := &g.LineScore
+
+	// nobody is batting between innings
+	if ls.InningState != "Top" && ls.InningState != "Bottom" {
+		return ""
+	}
+
+	base := func(p *Person) string {
+		if p != nil {
+			return "x"
+		}
+		return "o"
+	}
+
+	outs := " outs"
+	if ls.Outs == 1 {
+		outs = " out"
+	}
 
+	sd := &strings.Builder{}
+	sd.WriteString(nl + strconv.Itoa(ls.Balls) + "-" + strconv.Itoa(ls.Strikes) + ", " + strconv.Itoa(ls.Outs) + outs)
+	sd.WriteString(nl + " " + base(ls.Offense.Second))
+	sd.WriteString(nl + base(ls.Offense.Third) + " " + base(ls.Offense.First))
+
+	if ls.Offense.Batter.FullName != "" {
+		sd.WriteString(nl + "AB: " + lastName(ls.Offense.Batter.FullName))
+	}
+	if ls.Defense.Pitcher.FullName != "" {
+		sd.WriteString(nl + "P: " + lastName(ls.Defense.Pitcher.FullName))
+	}
+
+	return sd.String()
 }
 
 func (ui *UI) getGameScoreDisplay(g *Game) (s string) {
 
 	s = nl
 
-	if hasGameStarted(g.GameStatus.DetailedState) {
-		s = strconv.Itoa(g.LineScore.Scoring.Away.Runs) + nl + strconv.Itoa(g.LineScore.Scoring.Home.Runs)
+	if hasGameStarted(g.GameStatus.DetailedState) && !ui.hideSpoilers(g) {
+		s = ui.paint(strconv.Itoa(g.LineScore.Scoring.Away.Runs), ui.getTeamStyles(g, false)...) + nl +
+			ui.paint(strconv.Itoa(g.LineScore.Scoring.Home.Runs), ui.getTeamStyles(g, true)...)
 	}
 
 	return
 }
 
+// GenerateBoxscore builds the batting and pitching tables for each team of a game
+func (ui *UI) GenerateBoxscore(g *Game, b *Boxscore) string {
+
+	ts := &strings.Builder{}
+
+	ts.WriteString("------\nBox score for " + ui.getTeamDisplay(g, true) + " (" + ui.getInningDisplay(g) + ")\n")
+
+	if !hasGameStarted(g.GameStatus.DetailedState) {
+		ts.WriteString("Game has not started.\n")
+		return ts.String()
+	}
+
+	if ui.hideSpoilers(g) {
+		ts.WriteString(spoilerNotice)
+		return ts.String()
+	}
+
+	teams := []struct {
+		team *Team
+		box  *BoxscoreTeam
+	}{
+		{&g.Teams.Away.Team, &b.Teams.Away},
+		{&g.Teams.Home.Team, &b.Teams.Home},
+	}
+
+	for _, t := range teams {
+
+		table := tablewriter.NewWriter(ts)
+		table.SetHeader([]string{t.team.Abbreviation + " Batting", "AB", "R", "H", "RBI", "BB", "SO", "AVG"})
+
+		for _, p := range t.box.Batters() {
+			name := p.Person.FullName + " " + p.Position.Abbreviation
+			// substitutes don't have a batting order ending in 00
+			if !strings.HasSuffix(p.BattingOrder, "00") {
+				name = "  " + name
+			}
+			s := p.Stats.Batting
+			table.Append([]string{name, strconv.Itoa(s.AtBats), strconv.Itoa(s.Runs), strconv.Itoa(s.Hits),
+				strconv.Itoa(s.RBI), strconv.Itoa(s.BaseOnBalls), strconv.Itoa(s.StrikeOuts), p.SeasonStats.Batting.Avg})
+		}
+
+		s := t.box.TeamStats.Batting
+		table.SetFooter([]string{"Totals", strconv.Itoa(s.AtBats), strconv.Itoa(s.Runs), strconv.Itoa(s.Hits),
+			strconv.Itoa(s.RBI), strconv.Itoa(s.BaseOnBalls), strconv.Itoa(s.StrikeOuts), s.Avg})
+		table.Render()
+
+		table = tablewriter.NewWriter(ts)
+		table.SetHeader([]string{t.team.Abbreviation + " Pitching", "IP", "H", "R", "ER", "BB", "SO", "HR", "ERA"})
+
+		for _, p := range t.box.PitchersUsed() {
+			s := p.Stats.Pitching
+			table.Append([]string{p.Person.FullName, s.InningsPitched, strconv.Itoa(s.Hits), strconv.Itoa(s.Runs),
+				strconv.Itoa(s.EarnedRuns), strconv.Itoa(s.BaseOnBalls), strconv.Itoa(s.StrikeOuts),
+				strconv.Itoa(s.HomeRuns), p.SeasonStats.Pitching.ERA})
+		}
+
+		table.Render()
+	}
+
+	return ts.String()
+}
+
+// GenerateLineScores builds the line score of every started game on the scoreboard
+func (ui *UI) GenerateLineScores() string {
+
+	ts := &strings.Builder{}
+
+	for _, g := range *ui.schedule.Games {
+		if !ui.showGame(&g) || !hasGameStarted(g.GameStatus.DetailedState) {
+			continue
+		}
+		ts.WriteString(ui.GenerateLineScore(&g))
+	}
+
+	if ts.Len() == 0 {
+		ts.WriteString("No line scores available.\n")
+	}
+
+	return ts.String()
+}
+
+// GenerateLineScore builds the inning by inning line score of a game with R/H/E
+func (ui *UI) GenerateLineScore(g *Game) string {
+
+	ts := &strings.Builder{}
+	ls := &g.LineScore
+
+	ts.WriteString(ui.getTeamDisplay(g, true) + " (" + ui.getInningDisplay(g) + ")\n")
+
+	if ui.hideSpoilers(g) {
+		ts.WriteString(spoilerNotice)
+		return ts.String()
+	}
+
+	innings := ls.ScheduledInnings
+	if innings == 0 {
+		innings = 9
+	}
+	if len(ls.Innings) > innings {
+		innings = len(ls.Innings)
+	}
+
+	final := isCompleteGame(g.GameStatus.DetailedState)
+	header := []string{""}
+	away := []string{g.Teams.Away.Team.Abbreviation}
+	home := []string{g.Teams.Home.Team.Abbreviation}
+
+	for i := 0; i < innings; i++ {
+		header = append(header, strconv.Itoa(i+1))
+		if i < len(ls.Innings) {
+			away = append(away, inningRuns(ls.Innings[i].Away.Runs, final))
+			home = append(home, inningRuns(ls.Innings[i].Home.Runs, final))
+		} else {
+			away = append(away, "")
+			home = append(home, "")
+		}
+	}
+
+	header = append(header, "R", "H", "E")
+	for _, t := range []struct {
+		row   *[]string
+		score Score
+	}{{&away, ls.Scoring.Away}, {&home, ls.Scoring.Home}} {
+		*t.row = append(*t.row, strconv.Itoa(t.score.Runs), strconv.Itoa(t.score.Hits), strconv.Itoa(t.score.Errors))
+	}
+
+	table := tablewriter.NewWriter(ts)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader(header)
+	table.Append(away)
+	table.Append(home)
+	table.Render()
+
+	ts.WriteString("LOB: " + g.Teams.Away.Team.Abbreviation + " " + strconv.Itoa(ls.Scoring.Away.LeftOnBase) +
+		", " + g.Teams.Home.Team.Abbreviation + " " + strconv.Itoa(ls.Scoring.Home.LeftOnBase) + nl)
+
+	return ts.String()
+}
+
+// inningRuns shows an X for a half inning that wasn't needed in a final game
+func inningRuns(runs *int, final bool) string {
+	if runs == nil {
+		if final {
+			return "X"
+		}
+		return ""
+	}
+	return strconv.Itoa(*runs)
+}
+
+// GetPlayDisplay describes a completed play with the count, outs, runners and score
+func (ui *UI) GetPlayDisplay(g *Game, p *Play, scored bool) string {
+
+	d := &strings.Builder{}
+
+	if len(p.About.HalfInning) >= 3 {
+		d.WriteString(strings.ToUpper(p.About.HalfInning[0:1]) + p.About.HalfInning[1:3] + " " + strconv.Itoa(p.About.Inning) + " | ")
+	}
+
+	outs := " outs"
+	if p.Count.Outs == 1 {
+		outs = " out"
+	}
+	d.WriteString(strconv.Itoa(p.Count.Balls) + "-" + strconv.Itoa(p.Count.Strikes) + ", " + strconv.Itoa(p.Count.Outs) + outs + " | ")
+	d.WriteString(p.Result.Description)
+
+	var runners []string
+	for _, r := range []struct {
+		base   string
+		runner *Person
+	}{{"1st", p.Matchup.PostOnFirst}, {"2nd", p.Matchup.PostOnSecond}, {"3rd", p.Matchup.PostOnThird}} {
+		if r.runner != nil {
+			runners = append(runners, r.base)
+		}
+	}
+	if len(runners) > 0 && p.Count.Outs < 3 {
+		d.WriteString(" | Runners on " + strings.Join(runners, ", "))
+	}
+
+	if scored {
+		d.WriteString(nl + "    " + g.Teams.Away.Team.Abbreviation + " " + strconv.Itoa(p.Result.AwayScore) +
+			", " + g.Teams.Home.Team.Abbreviation + " " + strconv.Itoa(p.Result.HomeScore))
+	}
+
+	return d.String()
+}
+
+// GenerateStandings builds the division tables and wild card races
+func (ui *UI) GenerateStandings(st *Standings) string {
+
+	ts := &strings.Builder{}
+
+	ts.WriteString("------\nStandings for " + strconv.Itoa(st.Season) + "\n")
+
+	if len(st.Divisions) == 0 {
+		ts.WriteString("No standings available.\n")
+		return ts.String()
+	}
+
+	for _, r := range st.Divisions {
+		ui.generateStandingsTable(ts, r.Division.Name, "GB", r.TeamRecords, func(tr *TeamRecord) string {
+			return tr.GamesBack
+		})
+	}
+
+	for _, r := range st.WildCard {
+		ui.generateStandingsTable(ts, r.League.Name+" Wild Card", "WCGB", r.TeamRecords, func(tr *TeamRecord) string {
+			return tr.WildCardGamesBack
+		})
+	}
+
+	return ts.String()
+}
+
+func (ui *UI) generateStandingsTable(ts *strings.Builder, title string, gbTitle string, records []TeamRecord, gamesBack func(*TeamRecord) string) {
+
+	table := tablewriter.NewWriter(ts)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader([]string{title, "W", "L", "PCT", gbTitle, "L10", "STRK", "DIFF"})
+
+	for i := range records {
+		tr := &records[i]
+		diff := strconv.Itoa(tr.RunDifferential)
+		if tr.RunDifferential > 0 {
+			diff = "+" + diff
+		}
+		table.Append([]string{tr.Team.Name + " (" + tr.Team.Abbreviation + ")", strconv.Itoa(tr.Wins), strconv.Itoa(tr.Losses),
+			tr.WinningPercentage, gamesBack(tr), tr.LastTen(), tr.Streak.StreakCode, diff})
+	}
+
+	table.Render()
+}
+
+// GenerateTeamSchedule builds the tables of a team's last n results and next n games
+func (ui *UI) GenerateTeamSchedule(t *Team, s *Schedule, n int) string {
+
+	if n <= 0 {
+		return ""
+	}
+
+	ts := &strings.Builder{}
+	var results, upcoming []Game
+
+	now := time.Now()
+
+	for _, g := range *s.Games {
+		gt, _ := time.Parse(time.RFC3339, g.GameDate)
+		if isCompleteGame(g.GameStatus.DetailedState) {
+			results = append(results, g)
+		} else if isActiveGame(g.GameStatus.DetailedState) || gt.After(now) {
+			upcoming = append(upcoming, g)
+		}
+	}
+
+	if len(results) > n {
+		results = results[len(results)-n:]
+	}
+	if len(upcoming) > n {
+		upcoming = upcoming[:n]
+	}
+
+	ts.WriteString("------\n" + t.Name + " (" + t.Abbreviation + ")\n")
+
+	table := tablewriter.NewWriter(ts)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader([]string{"Last " + strconv.Itoa(len(results)), "Opponent", "Result"})
+	for _, g := range results {
+		if ui.hideSpoilers(&g) {
+			table.Append([]string{ui.getGameDateDisplay(&g), ui.getOpponentDisplay(t, &g), ui.getSpoilerFreeStatus(&g)})
+			continue
+		}
+		us, them := ui.getTeamSides(t, &g)
+		result := "L"
+		if us.Runs > them.Runs {
+			result = "W"
+		} else if us.Runs == them.Runs {
+			result = "T"
+		}
+		table.Append([]string{ui.getGameDateDisplay(&g), ui.getOpponentDisplay(t, &g), result + " " + strconv.Itoa(us.Runs) + "-" + strconv.Itoa(them.Runs)})
+	}
+	table.Render()
+
+	table = tablewriter.NewWriter(ts)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader([]string{"Next " + strconv.Itoa(len(upcoming)), "Opponent", "Time", "Probable Pitchers"})
+	for _, g := range upcoming {
+		status := ui.getInningDisplay(&g)
+		if !hasGameStarted(g.GameStatus.DetailedState) {
+			gt, _ := time.Parse(time.RFC3339, g.GameDate)
+			status = timeFormat(&gt, false)
+		}
+		table.Append([]string{ui.getGameDateDisplay(&g), ui.getOpponentDisplay(t, &g), status, ui.getProbablePitchersDisplay(&g)})
+	}
+	table.Render()
+
+	return ts.String()
+}
+
+// getTeamSides returns the line score of the team and their opponent
+func (ui *UI) getTeamSides(t *Team, g *Game) (us Score, them Score) {
+	if g.Teams.Home.Team.ID == t.ID {
+		return g.LineScore.Scoring.Home, g.LineScore.Scoring.Away
+	}
+	return g.LineScore.Scoring.Away, g.LineScore.Scoring.Home
+}
+
+func (ui *UI) getOpponentDisplay(t *Team, g *Game) string {
+	if g.Teams.Home.Team.ID == t.ID {
+		return "vs " + g.Teams.Away.Team.Abbreviation
+	}
+	return "@ " + g.Teams.Home.Team.Abbreviation
+}
+
+func (ui *UI) getGameDateDisplay(g *Game) string {
+	gt, err := time.Parse(time.RFC3339, g.GameDate)
+	if err != nil {
+		return ""
+	}
+	return gt.Local().Format("Mon Jan 2")
+}
+
+func (ui *UI) getProbablePitchersDisplay(g *Game) string {
+
+	name := func(p *Person) string {
+		if p == nil || p.FullName == "" {
+			return "TBD"
+		}
+		return lastName(p.FullName)
+	}
+
+	return name(g.Teams.Away.ProbablePitcher) + " vs " + name(g.Teams.Home.ProbablePitcher)
+}
+
 // Prompt for user input
 func (ui *UI) Prompt() (input string) {
 	reader := bufio.NewReader(os.Stdin)
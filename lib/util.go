@@ -3,7 +3,9 @@ package lib
 import (
 	"net"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -58,11 +60,67 @@ func httpGet(url string) (resp *http.Response, err error) {
 	return
 }
 
+// setQuery sets query parameters on a URL. Empty values remove the parameter.
+func setQuery(rawURL string, params map[string]string) (string, error) {
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	for k, v := range params {
+		if v == "" {
+			q.Del(k)
+		} else {
+			q.Set(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// addHydrations adds to the hydrate query parameter of a stats API URL
+func addHydrations(rawURL string, hydrations ...string) (string, error) {
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	hydrate := q.Get("hydrate")
+	for _, h := range hydrations {
+		// don't override a hydration already in the configured URL
+		if strings.Contains(hydrate, strings.SplitN(h, "(", 2)[0]) {
+			continue
+		}
+		if hydrate != "" {
+			hydrate += ","
+		}
+		hydrate += h
+	}
+
+	q.Set("hydrate", hydrate)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
 func match(pattern string, in string) bool {
 	m, _ := regexp.MatchString(pattern, in)
 	return m
 }
 
+// lastName drops the first name of a player, e.g. Vladimir Guerrero Jr. becomes Guerrero Jr.
+func lastName(name string) string {
+	if i := strings.Index(name, " "); i > 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
 func timeFormat(x *time.Time, showDate bool) string {
 	location, _ := time.LoadLocation("Local")
 	if showDate {
@@ -118,3 +176,15 @@ func isCompleteGame(state string) bool {
 	}
 	return false
 }
+
+func isPostseason(gameType string) bool {
+	switch gameType {
+	case
+		"F", // wild card
+		"D", // division series
+		"L", // league championship series
+		"W": // world series
+		return true
+	}
+	return false
+}
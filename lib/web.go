@@ -0,0 +1,21 @@
+package lib
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// webFiles are the dashboard's static files, built into the binary
+//
+//go:embed web
+var webFiles embed.FS
+
+// dashboardHandler serves the web dashboard
+func dashboardHandler() http.Handler {
+
+	// the web directory is always embedded
+	files, _ := fs.Sub(webFiles, "web")
+
+	return http.FileServer(http.FS(files))
+}
@@ -0,0 +1,170 @@
+// mlbme dashboard: shows the scoreboard and plays streams through the REST API
+
+// the scoreboard is updated as events arrive, polling is a fallback
+const refreshRate = 5 * 60 * 1000;
+const eventTypes = [
+  "gameStarted", "runScored", "leadChange", "inningChanged", "delayed", "final",
+  "streamAvailable", "streamEnded", "extraInnings", "closeGame", "noHitter", "refresh",
+];
+
+let playing = null;
+
+function el(tag, className, text) {
+  const e = document.createElement(tag);
+  if (className) {
+    e.className = className;
+  }
+  if (text !== undefined) {
+    e.textContent = text;
+  }
+  return e;
+}
+
+function showMessage(text) {
+  const m = document.getElementById("message");
+  m.textContent = text;
+  m.hidden = !text;
+}
+
+function gameStatus(g) {
+  if (g.inning) {
+    return g.inning;
+  }
+  if (g.state === "upcoming") {
+    return new Date(g.startTime).toLocaleTimeString([], { hour: "numeric", minute: "2-digit" });
+  }
+  return g.status;
+}
+
+function teamRow(team, other) {
+  const row = el("div", "team");
+  if (team.runs !== null && team.runs > other.runs) {
+    row.classList.add("leading");
+  }
+  row.append(el("span", "", team.name + " (" + team.abbreviation + ")"));
+  row.append(el("span", "", team.runs === null ? "" : String(team.runs)));
+  return row;
+}
+
+function streamButton(s) {
+  const b = el("button", "", s.feedType + " [" + s.callLetters + "]");
+  if (playing && playing.id === s.id) {
+    b.classList.add("playing");
+  }
+  b.addEventListener("click", () => play(s));
+  return b;
+}
+
+// seriesText shows the game's place in its series, e.g. ALCS Game 3 of 7 — NYY leads 2-0
+function seriesText(g) {
+  const st = g.seriesStatus;
+  let text = "Game " + g.seriesGameNumber + " of " + g.gamesInSeries;
+  if (st && st.shortName) {
+    text = st.shortName + " " + text;
+  }
+  if (st && st.result) {
+    text += " — " + st.result;
+  }
+  return text;
+}
+
+function gameCard(g) {
+  const card = el("div", "game " + g.state);
+
+  card.append(teamRow(g.away, g.home), teamRow(g.home, g.away));
+  card.append(el("div", "status", gameStatus(g) + (g.gameNumber > 1 ? " (Gm " + g.gameNumber + ")" : "")));
+
+  if (g.gamesInSeries > 0) {
+    card.append(el("div", "series", seriesText(g)));
+  }
+
+  if (g.streams.length > 0) {
+    const streams = el("div", "streams");
+    g.streams.forEach((s) => streams.append(streamButton(s)));
+    card.append(streams);
+  }
+
+  return card;
+}
+
+function render(schedule) {
+  const games = document.getElementById("games");
+  games.replaceChildren();
+
+  const multiDay = schedule.startDate !== schedule.endDate;
+  let date = "";
+
+  schedule.games.forEach((g) => {
+    if (multiDay && g.date !== date) {
+      date = g.date;
+      games.append(el("h2", "date", date));
+    }
+    games.append(gameCard(g));
+  });
+
+  if (schedule.games.length === 0) {
+    games.append(el("p", "", "No Games"));
+  }
+
+  document.getElementById("refreshed").textContent =
+    "as of " + new Date(schedule.lastRefreshed).toLocaleTimeString([], { hour: "numeric", minute: "2-digit" });
+}
+
+function renderPlayer() {
+  document.getElementById("player").hidden = !playing;
+  if (playing) {
+    document.getElementById("playing").textContent = "Playing " + playing.feedType + " [" + playing.callLetters + "]";
+  }
+}
+
+async function request(method, path, body) {
+  const resp = await fetch(path, {
+    method: method,
+    // the API only accepts JSON posts
+    headers: method === "POST" ? { "Content-Type": "application/json" } : {},
+    body: body ? JSON.stringify(body) : undefined,
+  });
+  const data = resp.status === 204 ? null : await resp.json();
+  if (!resp.ok) {
+    throw new Error(data && data.error ? data.error : resp.statusText);
+  }
+  return data;
+}
+
+async function update() {
+  try {
+    const [schedule, streams] = await Promise.all([request("GET", "/games"), request("GET", "/streams")]);
+    playing = streams.playing;
+    render(schedule);
+    renderPlayer();
+    showMessage("");
+  } catch (err) {
+    showMessage("Unable to update: " + err.message);
+  }
+}
+
+async function play(s) {
+  try {
+    await request("POST", "/play", { stream: s.id });
+    await update();
+  } catch (err) {
+    showMessage("Unable to play " + s.callLetters + ": " + err.message);
+  }
+}
+
+document.getElementById("stop").addEventListener("click", async () => {
+  try {
+    await request("POST", "/stop");
+    await update();
+  } catch (err) {
+    showMessage("Unable to stop: " + err.message);
+  }
+});
+
+const events = new EventSource("/events");
+for (const type of eventTypes) {
+  events.addEventListener(type, update);
+}
+
+update();
+setInterval(update, refreshRate);
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -20,88 +23,539 @@ var (
 	streamlink  lib.Streamlink
 	gamestreams lib.GameStreams
 	ui          lib.UI
+	tui         *lib.TUI
+	filter      lib.Filter
+	follower    lib.Follower
+	startDate   time.Time
+	endDate     time.Time
 	err         error
 	version     string
+
+	// lock guards the schedule and streams while they are refreshed
+	lock sync.RWMutex
+	// events are the changes found on each refresh
+	events   = lib.NewEventStream()
+	notifier lib.Notifier
+	// serving is set when running headless with the dashboard and REST API
+	serving bool
+
+	// out is where commands print, the message pane in full-screen mode
+	out io.Writer = os.Stdout
 )
 
+type standingsCmd struct{}
+
+type scoresCmd struct{}
+
+type streamsCmd struct{}
+
+type playCmd struct {
+	Stream     string `arg:"positional,required" help:"call letters or team of the stream"`
+	GameNumber int    `arg:"-g" help:"game number of a doubleheader"`
+}
+
+type watchCmd struct {
+	Interval time.Duration `arg:"-i" default:"5m" help:"time between refreshes"`
+}
+
+type teamCmd struct {
+	Abbreviation string `arg:"positional,required" help:"team abbreviation"`
+	Games        int    `arg:"-n" default:"5" help:"number of past and upcoming games to show"`
+}
+
 type args struct {
-	Config string `arg:"-c" help:"JSON configuration"`
-	HTTP   bool   `help:"use HTTP streaming instead of playing locally"`
-	Team   string `arg:"-t" help:"filter on team by abbreviation"`
-	Stream string `arg:"-s" help:"call letter of stream to start"`
-	Debug  bool   `help:"enable debug logging"`
+	Scores    *scoresCmd    `arg:"subcommand:scores" help:"print the scoreboard and exit"`
+	Streams   *streamsCmd   `arg:"subcommand:streams" help:"print the available streams and exit"`
+	Play      *playCmd      `arg:"subcommand:play" help:"play a stream until it ends"`
+	Watch     *watchCmd     `arg:"subcommand:watch" help:"print the scoreboard on each refresh"`
+	Standings *standingsCmd `arg:"subcommand:standings" help:"show league standings"`
+	TeamView  *teamCmd      `arg:"subcommand:team" help:"show a team's recent results and upcoming games"`
+	Config    string        `arg:"-c" help:"JSON configuration"`
+	HTTP      bool          `help:"use HTTP streaming instead of playing locally"`
+	Team      string        `arg:"-t" help:"filter on teams by comma separated abbreviations"`
+	League    string        `help:"filter on leagues: AL, NL"`
+	Division  string        `help:"filter on divisions, e.g. ALE,NLW"`
+	State     string        `help:"filter on game states: live, final, upcoming"`
+	HasStream bool          `help:"only show games with a stream available"`
+	NoSpoiler bool          `arg:"--no-spoilers" help:"hide scores and game states"`
+	Compact   bool          `help:"only show team abbreviations on the scoreboard"`
+	Notify    string        `help:"notify on favorite team events: auto, bell, osc9, osc777, notify-send or none"`
+	Filter    string        `arg:"-f" help:"filter expression, e.g. \"team=NYY,BOS state=live\""`
+	Level     string        `arg:"-l" help:"level of play: mlb, aaa, aa, high-a, single-a, rookie, spring, wbc"`
+	Stream    string        `arg:"-s" help:"call letter of stream to start"`
+	Date      string        `arg:"-d" help:"date of games to show (YYYY-MM-DD)"`
+	Start     string        `help:"first date of a range of games to show (YYYY-MM-DD)"`
+	End       string        `help:"last date of a range of games to show (YYYY-MM-DD)"`
+	Days      int           `help:"number of days of games to show"`
+	Boxscore  string        `help:"show box score of game by team abbreviation or gamePk"`
+	Output    string        `arg:"-o" help:"print the games and exit: table, json, csv or tsv"`
+	Plain     bool          `help:"use a line prompt instead of the full-screen interface"`
+	Serve     bool          `help:"serve the web dashboard and REST API instead of the prompt"`
+	Address   string        `help:"address of the REST API"`
+	Debug     bool          `help:"enable debug logging"`
 }
 
 // consts
 const (
 	RefreshRate = 5 * time.Minute
+	TeamGames   = 5
+)
+
+// exit codes
+const (
+	exitOK    = 0
+	exitError = 1
+	// no games, streams or teams matched
+	exitNotFound = 2
 )
 
 func init() {
 	// handle ctrl-c (sigterm)
-	stCh := make(chan os.Signal)
+	stCh := make(chan os.Signal, 1)
 	signal.Notify(stCh, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-stCh
+		if tui != nil {
+			tui.Close()
+		}
 		streamlink.Stop()
 		proxy.Stop()
 		os.Exit(1)
 	}()
 }
 
-func refresh(periodic bool) {
-	r := func() {
-		schedule, err = lib.GetMLBSchedule(config.StatsURL)
-		if err != nil {
-			exit(err)
+// refresh gets the schedule of the days and checks its streams. A schedule
+// for days no longer shown, after changing dates meanwhile, is dropped.
+func refresh(start, end time.Time) (err error) {
+
+	s, err := lib.GetMLBSchedule(config.StatsURL, config.GetLevel(), start, end)
+	if err != nil {
+		return
+	}
+
+	// checking streams is slow, so it's done before taking the lock
+	var found map[int]map[string]*lib.Stream
+	if config.CheckStreams {
+		found = gamestreams.CheckStreams(&s)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if !start.Equal(startDate) || !end.Equal(endDate) {
+		return
+	}
+
+	old, oldStreams := schedule, gamestreams.Copy()
+
+	schedule = s
+
+	if config.CheckStreams {
+		gamestreams.SetStreams(found)
+	}
+
+	// nothing to compare with on the first refresh
+	if old.GameMap != nil {
+		changes := ui.DiffSchedule(&old, oldStreams)
+		events.Publish(append(changes, lib.Event{Type: lib.EventRefresh}))
+
+		followed := 0
+		if g, ok := follower.Following(); ok {
+			followed = g.GamePk
 		}
 
-		if config.CheckStreams {
-			gamestreams.GetAvailableStreams()
+		for _, m := range ui.GetNotifications(changes, followed) {
+			notifier.Notify(m)
 		}
 	}
 
-	if !periodic {
-		r()
-	} else {
-		ticker := time.NewTicker(RefreshRate)
-		for range ticker.C {
-			r()
-			fmt.Print(ui.GenerateScoreboard())
+	return
+}
+
+// refreshPeriodically refreshes today's games at the refresh rate. The last
+// schedule is kept when a refresh fails.
+func refreshPeriodically() {
+
+	ticker := time.NewTicker(RefreshRate)
+	for range ticker.C {
+
+		lock.RLock()
+		start, end := startDate, endDate
+		lock.RUnlock()
+
+		// past and future days don't change
+		if !includesToday(start, end) {
+			continue
 		}
+
+		if err := refresh(start, end); err != nil {
+			fmt.Fprintln(out, "Unable to refresh schedule:", err)
+			continue
+		}
+
+		if !serving {
+			showScoreboard()
+		}
+	}
+}
+
+func includesToday(start, end time.Time) bool {
+	today := lib.DefaultScheduleDate().Format(lib.DateFormat)
+	return start.Format(lib.DateFormat) <= today && today <= end.Format(lib.DateFormat)
+}
+
+// changeDate shows the days from start through end, staying on the days
+// shown when their schedule can't be got
+func changeDate(start, end time.Time) {
+
+	lock.Lock()
+	prevStart, prevEnd := startDate, endDate
+	startDate, endDate = start, end
+	lock.Unlock()
+
+	if err := refresh(start, end); err != nil {
+		fmt.Fprintln(out, "Unable to get schedule:", err)
+		lock.Lock()
+		startDate, endDate = prevStart, prevEnd
+		lock.Unlock()
+		return
+	}
+
+	showScoreboard()
+}
+
+// shiftDates moves the selected range of days backward or forward by its length
+func shiftDates(direction int) {
+	days := int(endDate.Sub(startDate).Hours()/24+0.5) + 1
+	changeDate(startDate.AddDate(0, 0, direction*days), endDate.AddDate(0, 0, direction*days))
+}
+
+// parseFilter builds the game filter from the filter expression and options
+func parseFilter(args *args) (err error) {
+
+	expr := []string{args.Filter}
+
+	for _, f := range []struct {
+		key, value string
+	}{{"team", args.Team}, {"league", args.League}, {"division", args.Division}, {"state", args.State}} {
+		if f.value != "" {
+			expr = append(expr, f.key+"="+f.value)
+		}
+	}
+
+	if args.HasStream {
+		expr = append(expr, "streams")
+	}
+
+	filter, err = lib.ParseFilter(strings.Join(expr, " "))
+
+	return
+}
+
+func changeFilter(expr string) {
+
+	f, err := lib.ParseFilter(expr)
+	if err == nil {
+		err = f.CheckLevel(config.GetLevel())
+	}
+	if err != nil {
+		fmt.Fprintln(out, "Invalid filter:", err)
+		return
 	}
+
+	lock.Lock()
+	filter = f
+	lock.Unlock()
+
+	showScoreboard()
 }
 
-func startStream(streamID string, http bool) {
-	var strs []*lib.Stream
+func parseDates(args *args) (err error) {
+
+	startDate = lib.DefaultScheduleDate()
 
-	for _, gs := range gamestreams.Streams {
-		for _, s := range gs {
-			if s.ID == streamID || s.CallLetters == streamID {
-				strs = append(strs, s)
-			}
+	start := args.Date
+	if args.Start != "" {
+		start = args.Start
+	}
+
+	if start != "" {
+		if startDate, err = lib.ParseDate(start); err != nil {
+			return fmt.Errorf("invalid date %s", start)
+		}
+	}
+
+	endDate = startDate
+
+	if args.End != "" {
+		if endDate, err = lib.ParseDate(args.End); err != nil {
+			return fmt.Errorf("invalid date %s", args.End)
 		}
+	} else if args.Days > 1 {
+		endDate = startDate.AddDate(0, 0, args.Days-1)
+	}
+
+	if endDate.Before(startDate) {
+		return errors.New("end date is before start date")
 	}
 
+	return
+}
+
+func startStream(input string, http bool) {
+
+	strs := gamestreams.FindStreams(lib.ParseGameKey(input))
+
 	switch len(strs) {
 	case 0:
-		fmt.Println("Stream doesn't exist.")
+		fmt.Fprintln(out, "Stream doesn't exist.")
 	case 1:
-		if streamlink.Running {
-			streamlink.Stop()
+		streamlink.Stop()
+
+		if err := streamlink.Start(strs[0], http); err != nil {
+			fmt.Fprintln(out, "Unable to play stream:", err)
+			return
 		}
+		fmt.Fprintln(out, ui.GetStartStreamlinkDisplay(strs[0]))
+	default:
+		fmt.Fprintln(out, ui.GenerateStreamTable(strs))
+	}
+}
+
+// playStream plays a stream in the foreground until it ends
+func playStream(key string, gameNumber int, http bool) (code int) {
 
-		fmt.Println(ui.GetStartStreamlinkDisplay(strs[0]))
-		go streamlink.Run(strs[0], http)
+	strs := gamestreams.FindStreams(key, gameNumber)
+
+	switch len(strs) {
+	case 0:
+		fmt.Fprintln(out, "Stream doesn't exist.")
+		return exitNotFound
+	case 1:
+		fmt.Fprintln(out, ui.GetStartStreamlinkDisplay(strs[0]))
+		if err := streamlink.Run(strs[0], http); err != nil {
+			fmt.Fprintln(out, err)
+			return exitError
+		}
+		return exitOK
 	default:
-		fmt.Println(ui.GenerateStreamTable(strs))
+		fmt.Fprintln(out, ui.GenerateStreamTable(strs))
+		return exitNotFound
+	}
+}
+
+// printScores prints the scoreboard in the output format
+func printScores(format string) (code int) {
+
+	o, err := ui.GenerateOutput(format)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return exitError
+	}
+
+	fmt.Fprint(out, o)
+
+	if len(ui.GetScheduleOutput().Games) == 0 {
+		return exitNotFound
+	}
+
+	return exitOK
+}
+
+// printStreams prints the streams of the games on the scoreboard
+func printStreams(format string) (code int) {
+
+	filter.HasStream = true
+
+	if format != lib.OutputTable {
+		return printScores(format)
+	}
+
+	fmt.Fprint(out, ui.GenerateStreamList())
+
+	if len(ui.GetScheduleOutput().Games) == 0 {
+		return exitNotFound
+	}
+
+	return exitOK
+}
+
+// watch prints the scoreboard each time it's refreshed until interrupted
+func watch(format string, interval time.Duration) {
+
+	clear := format == lib.OutputTable && lib.IsTerminal(os.Stdout)
+
+	for {
+		if clear {
+			fmt.Fprint(out, "\x1b[H\x1b[2J")
+		}
+
+		if printScores(format) == exitError {
+			exit(errors.New("unable to show scoreboard"))
+		}
+
+		time.Sleep(interval)
+
+		// keep printing the last schedule until the stats API is back, on
+		// stderr so the error isn't mixed into the output
+		if err := refresh(startDate, endDate); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to refresh schedule:", err)
+		}
+	}
+}
+
+// playFavorite starts the stream of the first favorite team with one available
+func playFavorite(http bool) {
+
+	for _, f := range config.Favorites {
+		if len(gamestreams.FindStreams(f, 0)) > 0 {
+			startStream(f, http)
+			return
+		}
+	}
+
+	fmt.Fprintln(out, "No streams available for favorite teams.")
+}
+
+// showBoxscore gets a game's box score. The lock isn't held while getting it
+// so refreshes and the screen aren't held up.
+func showBoxscore(key string) {
+
+	lock.RLock()
+	g, ok := schedule.FindGame(key)
+	lock.RUnlock()
+
+	if !ok {
+		fmt.Fprintln(out, "Game not found.")
+		return
+	}
+
+	b, err := lib.GetBoxscore(config.BoxscoreURL, g.GamePk)
+	if err != nil {
+		fmt.Fprintln(out, "Unable to get box score:", err)
+		return
+	}
+
+	lock.RLock()
+	bs := ui.GenerateBoxscore(&g, &b)
+	lock.RUnlock()
+
+	fmt.Fprint(out, bs)
+}
+
+func showLineScore(key string) {
+
+	g, ok := schedule.FindGame(key)
+	if !ok {
+		fmt.Fprintln(out, "Game not found.")
+		return
+	}
+
+	fmt.Fprint(out, ui.GenerateLineScore(&g))
+}
+
+func followGame(key string) {
+
+	g, ok := schedule.FindGame(key)
+	if !ok {
+		fmt.Fprintln(out, "Game not found.")
+		return
+	}
+
+	fmt.Fprintln(out, "Following "+g.Teams.Away.Team.Abbreviation+" vs "+g.Teams.Home.Team.Abbreviation+"...")
+	follower.Start(g)
+}
+
+func showStandings() (code int) {
+
+	if l := config.GetLevel(); !l.HasStandings() {
+		fmt.Fprintln(out, "Standings are only available for MLB, not "+l.Name+".")
+		return exitError
+	}
+
+	st, err := lib.GetStandings(config.StandingsURL, startDate.Year())
+	if err != nil {
+		fmt.Fprintln(out, "Unable to get standings:", err)
+		return exitError
+	}
+
+	fmt.Fprint(out, ui.GenerateStandings(&st))
+
+	return exitOK
+}
+
+func showTeam(abbreviation string, n int) (code int) {
+
+	teams, err := lib.GetTeams(config.TeamsURL, config.GetLevel())
+	if err != nil {
+		fmt.Fprintln(out, "Unable to get teams:", err)
+		return exitError
 	}
+
+	t, ok := lib.FindTeam(teams, abbreviation)
+	if !ok {
+		fmt.Fprintln(out, "Team not found.")
+		return exitNotFound
+	}
+
+	// wide enough to cover off days
+	days := n*2 + 7
+
+	s, err := lib.GetTeamSchedule(config.StatsURL, config.GetLevel(), t.ID, startDate.AddDate(0, 0, -days), startDate.AddDate(0, 0, days))
+	if err != nil {
+		fmt.Fprintln(out, "Unable to get team schedule:", err)
+		return exitError
+	}
+
+	fmt.Fprint(out, ui.GenerateTeamSchedule(&t, &s, n))
+
+	return exitOK
+}
+
+func revealGame(key string) {
+
+	lock.Lock()
+	g, ok := schedule.FindGame(key)
+	if ok {
+		ui.Reveal(&g)
+	}
+	lock.Unlock()
+
+	if !ok {
+		fmt.Fprintln(out, "Game not found.")
+		return
+	}
+
+	showScoreboard()
+}
+
+// showScoreboard prints the scoreboard, or redraws it in place in full-screen mode
+func showScoreboard() {
+	if tui != nil {
+		tui.Redraw()
+		return
+	}
+
+	lock.RLock()
+	sb := ui.GenerateScoreboard()
+	lock.RUnlock()
+
+	fmt.Fprint(out, sb)
 }
 
 func exit(err error) {
-	code := 0
+	code := exitOK
+	if err != nil {
+		code = exitError
+	}
+	exitWith(code, err)
+}
+
+func exitWith(code int, err error) {
+	if tui != nil {
+		tui.Close()
+	}
 	if err != nil {
-		code = 1
 		fmt.Println("ERROR:", err)
 	}
 	streamlink.Stop()
@@ -117,6 +571,7 @@ func main() {
 
 	var args args
 	args.Config = "config.json"
+	args.Address = lib.DefaultAddress
 	arg.MustParse(&args)
 
 	log.SetOutput(os.Stdout)
@@ -131,11 +586,72 @@ func main() {
 
 	log.Debug("Debug logging enabled")
 
+	if err = parseDates(&args); err != nil {
+		exit(err)
+	}
+
+	if err = parseFilter(&args); err != nil {
+		exit(err)
+	}
+
+	if args.Output != "" {
+		if err = lib.CheckOutputFormat(args.Output); err != nil {
+			exit(err)
+		}
+	}
+
+	if args.Watch != nil && args.Watch.Interval <= 0 {
+		exit(errors.New("watch -i must be longer than 0"))
+	}
+
+	if args.TeamView != nil && args.TeamView.Games < 1 {
+		exit(errors.New("team -n must be at least 1"))
+	}
+
 	config, err = lib.LoadConfig(args.Config)
 	if err != nil {
 		exit(err)
 	}
 
+	if args.NoSpoiler {
+		config.NoSpoilers = true
+	}
+
+	if args.Compact {
+		config.Compact = true
+	}
+
+	if args.Notify != "" {
+		if err = lib.CheckNotifyMethod(args.Notify); err != nil {
+			exit(err)
+		}
+		config.Notify = args.Notify
+	}
+
+	if args.Level != "" {
+		if err = config.SetLevel(args.Level); err != nil {
+			exit(err)
+		}
+	}
+
+	if err = filter.CheckLevel(config.GetLevel()); err != nil {
+		exit(err)
+	}
+
+	if args.Standings != nil {
+		ui = lib.NewUI(config, &schedule, nil, &filter)
+		exitWith(showStandings(), nil)
+	}
+
+	if args.TeamView != nil {
+		ui = lib.NewUI(config, &schedule, nil, &filter)
+		exitWith(showTeam(args.TeamView.Abbreviation, args.TeamView.Games), nil)
+	}
+
+	if (args.Streams != nil || args.Play != nil) && !config.CheckStreams {
+		exit(errors.New("set checkStreams in configuration file to find streams"))
+	}
+
 	if config.CheckStreams {
 
 		proxy, err = lib.NewProxy(config)
@@ -154,29 +670,130 @@ func main() {
 
 	}
 
-	refresh(false)
+	if err = refresh(startDate, endDate); err != nil {
+		exit(err)
+	}
+
+	ui = lib.NewUI(config, &schedule, gamestreams.Streams, &filter)
+	notifier = lib.NewNotifier(config.Notify)
+
+	format := lib.OutputTable
+	if args.Output != "" {
+		format = args.Output
+	}
+
+	switch {
+	case args.Scores != nil:
+		exitWith(printScores(format), nil)
+	case args.Streams != nil:
+		exitWith(printStreams(format), nil)
+	case args.Play != nil:
+		exitWith(playStream(strings.ToUpper(args.Play.Stream), args.Play.GameNumber, args.HTTP), nil)
+	case args.Watch != nil:
+		watch(format, args.Watch.Interval)
+	case args.Output != "":
+		// --output without a command prints the scoreboard
+		exitWith(printScores(format), nil)
+	}
+
+	follower = lib.NewFollower(config, &ui)
 
-	ui = lib.NewUI(config, &schedule, gamestreams.Streams, args.Team)
+	if args.Serve {
+		serving = true
+		server := lib.NewServer(&ui, &gamestreams, &streamlink, events, &lock, args.HTTP)
+		go refreshPeriodically()
+		fmt.Println("Serving dashboard and API on http://" + args.Address)
+		exit(server.ListenAndServe(args.Address))
+	}
 
-	fmt.Print(ui.GenerateScoreboard())
+	if !args.Plain && lib.IsTerminal(os.Stdin) && lib.IsTerminal(os.Stdout) {
+		tui = lib.NewTUI(&ui, &streamlink, &follower, &lock)
+		out = tui
+		streamlink.Output = out
+		follower.Output = out
+		notifier.Output = tui.Terminal()
+		log.SetOutput(out)
+	}
+
+	showScoreboard()
 
 	// setup background refresh
-	go refresh(true)
+	go refreshPeriodically()
+
+	if args.Boxscore != "" {
+		showBoxscore(args.Boxscore)
+	}
 
 	if args.Stream != "" {
 		startStream(strings.ToUpper(args.Stream), args.HTTP)
 	}
 
+	if tui != nil {
+		exit(tui.Run(func(input string) {
+			runCommand(input, args.HTTP)
+		}))
+	}
+
 	for {
-		input := ui.Prompt()
-		if input == "Q" {
-			exit(nil)
-		} else if input == "R" || input == "" {
-			fmt.Print(ui.GenerateScoreboard())
-		} else if input == "H" {
-			fmt.Println("[call letters] = play stream\nr = refresh\nq = quit")
-		} else {
-			startStream(input, args.HTTP)
+		runCommand(ui.Prompt(), args.HTTP)
+	}
+}
+
+// runCommand runs a prompt command
+func runCommand(input string, http bool) {
+
+	// these refresh, show the scoreboard or make requests, and take the lock themselves
+	if input == "Q" {
+		exit(nil)
+	} else if input == "R" || input == "" {
+		showScoreboard()
+	} else if input == "<" {
+		shiftDates(-1)
+	} else if input == ">" {
+		shiftDates(1)
+	} else if strings.HasPrefix(input, "D ") {
+		d, err := lib.ParseDate(strings.TrimSpace(input[2:]))
+		if err != nil {
+			fmt.Fprintln(out, "Invalid date. Use YYYY-MM-DD.")
+			return
 		}
+		changeDate(d, d)
+	} else if input == "F" || strings.HasPrefix(input, "F ") {
+		changeFilter(input[1:])
+	} else if strings.HasPrefix(input, "REVEAL ") {
+		revealGame(strings.TrimSpace(input[7:]))
+	} else if strings.HasPrefix(input, "B ") {
+		showBoxscore(strings.TrimSpace(input[2:]))
+	} else if input == "S" {
+		showStandings()
+	} else if strings.HasPrefix(input, "TEAM ") {
+		showTeam(strings.TrimSpace(input[5:]), TeamGames)
+	} else {
+		lock.RLock()
+		defer lock.RUnlock()
+		runGameCommand(input, http)
+	}
+}
+
+// runGameCommand runs the commands that read the schedule and streams without
+// making requests
+func runGameCommand(input string, http bool) {
+
+	if input == "LINE" {
+		fmt.Fprint(out, ui.GenerateLineScores())
+	} else if strings.HasPrefix(input, "LINE ") {
+		showLineScore(strings.TrimSpace(input[5:]))
+	} else if strings.HasPrefix(input, "FOLLOW ") {
+		followGame(strings.TrimSpace(input[7:]))
+	} else if input == "UNFOLLOW" {
+		follower.Stop()
+	} else if input == "P" {
+		playFavorite(http)
+	} else if input == "STOP" {
+		streamlink.Stop()
+	} else if input == "H" {
+		fmt.Fprintln(out, "[call letters|team] [game number] = play stream\np = play favorite team's stream\nstop = stop the running stream\n< / > = previous / next day(s)\nd YYYY-MM-DD = go to date\nb [team|gamePk] = box score\nline [team|gamePk] = line score of a game or the scoreboard\nfollow [team|gamePk] = print plays as they happen\nunfollow = stop following game\nreveal [team|gamePk] = show score hidden to avoid spoilers\ns = standings\nf [expression] = filter games, e.g. f team=NYY,BOS state=live (f clears)\nteam [team] = team results and upcoming games\nr = refresh\nq = quit")
+	} else {
+		startStream(input, http)
 	}
 }
@@ -8,11 +8,22 @@ else
 	TZ=UTC
 fi
 
+# listen on all interfaces so the dashboard can be reached from outside the container
+case " $* " in
+*" --serve "*)
+	case " $* " in
+	*" --address "*) ;;
+	*) set -- "$@" --address :8080 ;;
+	esac
+	;;
+esac
+
 docker rm -f mlbme >/dev/null 2>&1
 
 docker run \
 	--env TZ=${TZ} \
     --name mlbme \
 	-p 6789:6789 \
+	-p 8080:8080 \
 	-v $(pwd)/config.json:/app/config.json \
-	-it dtpoole/mlbme $@
+	-it dtpoole/mlbme "$@"